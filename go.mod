@@ -5,13 +5,13 @@ go 1.14
 require (
 	github.com/davecgh/go-spew v1.1.1
 	github.com/facebookincubator/ent v0.1.4
+	github.com/go-sql-driver/mysql v1.5.1-0.20200311113236-681ffa848bae
 	github.com/google/uuid v1.1.1
-	github.com/jmoiron/sqlx v1.2.0
 	github.com/kr/pretty v0.2.0 // indirect
 	github.com/lib/pq v1.2.0
+	github.com/mattn/go-sqlite3 v1.11.0
 	github.com/onsi/ginkgo v1.12.0
 	github.com/onsi/gomega v1.9.0
-	github.com/google/uuid v0.0.0-20140804021211-a0b114877d4c
 	golang.org/x/xerrors v0.0.0-20191204190536-9bdfabe68543
 	gopkg.in/check.v1 v1.0.0-20180628173108-788fd7840127 // indirect
 )
@@ -0,0 +1,173 @@
+package integration_test
+
+import (
+	"context"
+	"database/sql"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/facebookincubator/ent/dialect"
+	entsql "github.com/facebookincubator/ent/dialect/sql"
+	"github.com/phogolabs/ent/integration/ent"
+)
+
+// explainDriver records the last query, so its plan can be explained.
+type explainDriver struct {
+	dialect.Driver
+	query string
+	args  []interface{}
+}
+
+func (d *explainDriver) Query(ctx context.Context, query string, args, v interface{}) error {
+	d.query = query
+	d.args, _ = args.([]interface{})
+	return d.Driver.Query(ctx, query, args, v)
+}
+
+// explain returns the plan of the last query.
+func (d *explainDriver) explain(ctx context.Context, db *sql.DB) (string, error) {
+	statement := "EXPLAIN "
+
+	if d.Dialect() == dialect.SQLite {
+		statement = "EXPLAIN QUERY PLAN "
+	}
+
+	rows, err := db.QueryContext(ctx, statement+d.query, d.args...)
+	if err != nil {
+		return "", err
+	}
+	defer rows.Close()
+
+	plan := []string{}
+
+	for rows.Next() {
+		var (
+			line   string
+			values = []interface{}{&line}
+		)
+
+		// the plan of SQLite has the id, parent and notused columns before the detail
+		if d.Dialect() == dialect.SQLite {
+			values = []interface{}{new(int), new(int), new(int), &line}
+		}
+
+		if err := rows.Scan(values...); err != nil {
+			return "", err
+		}
+
+		plan = append(plan, line)
+	}
+
+	return strings.Join(plan, "\n"), rows.Err()
+}
+
+func BenchmarkSeek(b *testing.B) {
+	b.Run("Postgres", func(b *testing.B) {
+		dsn := os.Getenv("ENT_POSTGRES_DSN")
+		if dsn == "" {
+			b.Skip("ENT_POSTGRES_DSN is not set")
+		}
+
+		benchmarkSeek(b, dialect.Postgres, dsn,
+			`INSERT INTO products (id, title, created_at, updated_at)
+			   SELECT md5(random()::text || g)::uuid, 'Product ' || (g % 100), now(), now()
+			   FROM generate_series(1, 10000) AS g`,
+			"ANALYZE products",
+		)
+	})
+
+	// the row values are compared since SQLite 3.15, so the plans check its version too
+	b.Run("SQLite", func(b *testing.B) {
+		benchmarkSeek(b, dialect.SQLite, "file:benchmark?mode=memory&cache=shared&_fk=1",
+			`WITH RECURSIVE g(n) AS (SELECT 1 UNION ALL SELECT n + 1 FROM g WHERE n < 10000)
+			 INSERT INTO products (id, title, created_at, updated_at)
+			   SELECT lower(substr(h, 1, 8) || '-' || substr(h, 9, 4) || '-' || substr(h, 13, 4) || '-' ||
+			     substr(h, 17, 4) || '-' || substr(h, 21)), 'Product ' || (n % 100), datetime('now'), datetime('now')
+			   FROM (SELECT n, hex(randomblob(16)) AS h FROM g)`,
+			"ANALYZE",
+		)
+	})
+}
+
+// benchmarkSeek seeds the products of the database with the statements and
+// benchmarks the query of the page after the first one.
+func benchmarkSeek(b *testing.B, name, dsn string, statements ...string) {
+	ctx := context.TODO()
+
+	drv, err := entsql.Open(name, dsn)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	explain := &explainDriver{Driver: drv}
+	client := ent.NewClient(ent.Driver(explain))
+	defer client.Close()
+
+	if err := client.Schema.Create(ctx); err != nil {
+		b.Fatal(err)
+	}
+
+	db := drv.DB()
+
+	// the index product_title_id is created by the migration of the orderings
+	for _, statement := range statements {
+		if _, err := db.ExecContext(ctx, statement); err != nil {
+			b.Fatal(err)
+		}
+	}
+
+	defer func() {
+		if _, err := db.ExecContext(ctx, "DELETE FROM products"); err != nil {
+			b.Fatal(err)
+		}
+	}()
+
+	// page runs the query of the page after the first one
+	page := func(b *testing.B, order string) {
+		cursor, err := ent.DecodeProductCursor(order, "")
+		if err != nil {
+			b.Fatal(err)
+		}
+
+		records, err := client.Product.Query().Seek(cursor).Limit(20).All(ctx)
+		if err != nil {
+			b.Fatal(err)
+		}
+
+		if cursor, err = cursor.Next(records); err != nil {
+			b.Fatal(err)
+		}
+
+		if _, err := client.Product.Query().Seek(cursor).Limit(20).All(ctx); err != nil {
+			b.Fatal(err)
+		}
+	}
+
+	// the directions of the row value comparison match the ones of the index
+	b.Run("RowValue", func(b *testing.B) {
+		page(b, "+title,+id")
+
+		plan, err := explain.explain(ctx, db)
+		if err != nil {
+			b.Fatal(err)
+		}
+
+		if !strings.Contains(plan, "product_title_id") {
+			b.Fatalf("the seek does not use the index:\n%s", plan)
+		}
+
+		b.ResetTimer()
+
+		for i := 0; i < b.N; i++ {
+			page(b, "+title,+id")
+		}
+	})
+
+	// the mixed directions fall back to the expanded comparison
+	b.Run("Expansion", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			page(b, "+title,-id")
+		}
+	})
+}
@@ -0,0 +1,399 @@
+package integration_test
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/facebookincubator/ent/dialect"
+	"github.com/go-sql-driver/mysql"
+	"github.com/phogolabs/ent/integration/ent"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+// The specs run against each dialect listed by the ENT_DIALECTS environment
+// variable (e.g. ENT_DIALECTS=sqlite3,postgres,mysql), which defaults to SQLite
+// in memory. The Postgres and MySQL servers are given by the ENT_POSTGRES_DSN and
+// ENT_MYSQL_DSN variables, or spawned from the local binaries when they are not
+// set. The specs of a dialect are skipped when its server is not available. The
+// full-text search of SQLite requires the sqlite_fts5 build tag of its driver
+// (e.g. go test -tags sqlite_fts5 ./...), and its specs are skipped without it.
+var dialects = parseDialects(os.Getenv("ENT_DIALECTS"))
+
+// sequence numbers the databases of the specs.
+var sequence uint64
+
+func parseDialects(value string) []*dialectServer {
+	servers := []*dialectServer{}
+
+	for _, name := range strings.Split(value, ",") {
+		switch name = strings.TrimSpace(name); name {
+		case "":
+		case "sqlite", "sqlite3":
+			servers = append(servers, &dialectServer{name: dialect.SQLite})
+		case "postgres", "postgresql":
+			servers = append(servers, &dialectServer{name: dialect.Postgres, dsn: os.Getenv("ENT_POSTGRES_DSN")})
+		case "mysql":
+			servers = append(servers, &dialectServer{name: dialect.MySQL, dsn: os.Getenv("ENT_MYSQL_DSN")})
+		default:
+			panic(fmt.Sprintf("unknown '%s' dialect in ENT_DIALECTS", name))
+		}
+	}
+
+	if len(servers) == 0 {
+		servers = append(servers, &dialectServer{name: dialect.SQLite})
+	}
+
+	return servers
+}
+
+// dialectServer represents the server of a dialect, which hosts the databases of the specs.
+type dialectServer struct {
+	name string
+	dsn  string
+	// db is the administrative connection, which creates and drops the databases.
+	db *sql.DB
+	// err is the reason the server is not available.
+	err error
+	// stop stops the server when it is spawned by the suite.
+	stop func() error
+}
+
+// start connects to the server of the dialect, spawning it from the local
+// binaries when it has no DSN.
+func (d *dialectServer) start() {
+	if d.name == dialect.SQLite {
+		return
+	}
+
+	if d.dsn == "" {
+		switch d.name {
+		case dialect.Postgres:
+			d.dsn, d.stop, d.err = spawnPostgres()
+		case dialect.MySQL:
+			d.dsn, d.stop, d.err = spawnMySQL()
+		}
+
+		if d.err != nil {
+			return
+		}
+	}
+
+	if d.db, d.err = sql.Open(d.name, d.dsn); d.err != nil {
+		return
+	}
+
+	d.err = ping(d.db)
+}
+
+// close closes the administrative connection and stops the spawned server.
+func (d *dialectServer) close() error {
+	if d.db != nil {
+		if err := d.db.Close(); err != nil {
+			return err
+		}
+	}
+
+	if d.stop != nil {
+		return d.stop()
+	}
+
+	return nil
+}
+
+func (d *dialectServer) exec(statement string) error {
+	_, err := d.db.Exec(statement)
+	return err
+}
+
+// database represents an isolated database of a dialect, which is created before
+// each spec and dropped after it. The names of the databases are unique across
+// the processes of a parallel run, so the specs can share a server.
+type database struct {
+	server  *dialectServer
+	name    string
+	dsn     string
+	clients []*ent.Client
+	// migrated reports whether the schema of the database was created, since
+	// the SQLite migration of v0.1.4 cannot run again on an existing schema.
+	migrated bool
+}
+
+// create creates the database of the spec.
+func (db *database) create() error {
+	if err := db.server.err; err != nil {
+		Skip(fmt.Sprintf("%s is not available: %v", db.server.name, err))
+	}
+
+	db.name = fmt.Sprintf("ent_%d_%d", os.Getpid(), atomic.AddUint64(&sequence, 1))
+	db.clients = nil
+	db.migrated = false
+
+	switch db.server.name {
+	case dialect.SQLite:
+		// the in-memory database is dropped with its last connection
+		db.dsn = fmt.Sprintf("file:%s?mode=memory&cache=shared&_fk=1", db.name)
+		return nil
+	case dialect.Postgres:
+		db.dsn = postgresSchema(db.server.dsn, db.name)
+		return db.server.exec(fmt.Sprintf("CREATE SCHEMA %q", db.name))
+	case dialect.MySQL:
+		config, err := mysql.ParseDSN(db.server.dsn)
+		if err != nil {
+			return err
+		}
+
+		config.DBName = db.name
+		config.ParseTime = true
+
+		db.dsn = config.FormatDSN()
+		return db.server.exec(fmt.Sprintf("CREATE DATABASE `%s`", db.name))
+	default:
+		return fmt.Errorf("unknown '%s' dialect", db.server.name)
+	}
+}
+
+// drop closes the clients of the spec and drops its database.
+func (db *database) drop() error {
+	for _, client := range db.clients {
+		if err := client.Close(); err != nil {
+			return err
+		}
+	}
+
+	db.clients = nil
+
+	switch db.server.name {
+	case dialect.Postgres:
+		return db.server.exec(fmt.Sprintf("DROP SCHEMA IF EXISTS %q CASCADE", db.name))
+	case dialect.MySQL:
+		return db.server.exec(fmt.Sprintf("DROP DATABASE IF EXISTS `%s`", db.name))
+	default:
+		return nil
+	}
+}
+
+// dialect returns the name of the dialect.
+func (db *database) dialect() string {
+	return db.server.name
+}
+
+// open opens a client of the database with the migrated schema, which is created
+// by the first client of the spec. The client is closed after the spec.
+func (db *database) open(opts ...ent.Option) *ent.Client {
+	client, err := ent.Open(db.server.name, db.dsn, opts...)
+	Expect(err).NotTo(HaveOccurred())
+
+	db.clients = append(db.clients, client)
+
+	if !db.migrated {
+		Expect(client.Schema.Create(context.TODO())).To(Succeed())
+		db.migrated = true
+	}
+
+	return client
+}
+
+// exec executes the statement in the database of the spec.
+func (db *database) exec(ctx context.Context, statement string, args ...interface{}) error {
+	conn, err := sql.Open(db.server.name, db.dsn)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	_, err = conn.ExecContext(ctx, statement, args...)
+	return err
+}
+
+// only skips the spec unless it runs in one of the dialects.
+func (db *database) only(names ...string) {
+	for _, name := range names {
+		if name == db.server.name {
+			return
+		}
+	}
+
+	Skip(fmt.Sprintf("the spec is not supported by %s", db.server.name))
+}
+
+// describeDialects describes the specs once for each dialect of the suite. The
+// body receives the database of the current spec.
+func describeDialects(text string, body func(db *database)) bool {
+	for _, server := range dialects {
+		db := &database{server: server}
+
+		Describe(fmt.Sprintf("%s [%s]", text, server.name), func() {
+			BeforeEach(func() {
+				Expect(db.create()).To(Succeed())
+			})
+
+			AfterEach(func() {
+				Expect(db.drop()).To(Succeed())
+			})
+
+			body(db)
+		})
+	}
+
+	return true
+}
+
+// postgresSchema returns the DSN whose search path is the schema.
+func postgresSchema(dsn, schema string) string {
+	if u, err := url.Parse(dsn); err == nil && (u.Scheme == "postgres" || u.Scheme == "postgresql") {
+		query := u.Query()
+		query.Set("search_path", schema)
+		u.RawQuery = query.Encode()
+		return u.String()
+	}
+
+	return dsn + " search_path=" + schema
+}
+
+// spawnPostgres initializes a Postgres cluster in a temporary directory and starts
+// a server that listens only on a unix socket in it.
+func spawnPostgres() (string, func() error, error) {
+	initdb, err := lookBinary("initdb")
+	if err != nil {
+		return "", nil, err
+	}
+
+	ctl, err := lookBinary("pg_ctl")
+	if err != nil {
+		return "", nil, err
+	}
+
+	dir, err := ioutil.TempDir("", "ent-postgres")
+	if err != nil {
+		return "", nil, err
+	}
+
+	var (
+		data = filepath.Join(dir, "data")
+		stop = func() error {
+			err := run(ctl, "stop", "-D", data, "-m", "immediate", "-w")
+			os.RemoveAll(dir)
+			return err
+		}
+	)
+
+	if err := run(initdb, "-D", data, "-U", "ent", "-A", "trust", "-E", "UTF8", "--no-sync"); err != nil {
+		os.RemoveAll(dir)
+		return "", nil, err
+	}
+
+	options := fmt.Sprintf("-k %s -c listen_addresses='' -F", dir)
+
+	if err := run(ctl, "start", "-D", data, "-l", filepath.Join(dir, "server.log"), "-o", options, "-w"); err != nil {
+		os.RemoveAll(dir)
+		return "", nil, err
+	}
+
+	return fmt.Sprintf("host=%s user=ent dbname=postgres sslmode=disable", dir), stop, nil
+}
+
+// spawnMySQL initializes a MySQL data directory in a temporary directory and starts
+// a server that listens only on a unix socket in it.
+func spawnMySQL() (string, func() error, error) {
+	mysqld, err := lookBinary("mysqld")
+	if err != nil {
+		return "", nil, err
+	}
+
+	dir, err := ioutil.TempDir("", "ent-mysql")
+	if err != nil {
+		return "", nil, err
+	}
+
+	args := []string{
+		"--no-defaults",
+		"--datadir=" + filepath.Join(dir, "data"),
+		"--socket=" + filepath.Join(dir, "mysqld.sock"),
+		"--pid-file=" + filepath.Join(dir, "mysqld.pid"),
+		"--log-error=" + filepath.Join(dir, "server.log"),
+		"--skip-networking",
+	}
+
+	if os.Geteuid() == 0 {
+		args = append(args, "--user=root")
+	}
+
+	if err := run(mysqld, append(args, "--initialize-insecure")...); err != nil {
+		os.RemoveAll(dir)
+		return "", nil, err
+	}
+
+	cmd := exec.Command(mysqld, args...)
+
+	if err := cmd.Start(); err != nil {
+		os.RemoveAll(dir)
+		return "", nil, err
+	}
+
+	stop := func() error {
+		defer os.RemoveAll(dir)
+
+		if err := cmd.Process.Signal(os.Interrupt); err != nil {
+			return err
+		}
+
+		// the server exits with an error status when it is interrupted
+		cmd.Wait()
+		return nil
+	}
+
+	return fmt.Sprintf("root@unix(%s)/", filepath.Join(dir, "mysqld.sock")), stop, nil
+}
+
+// lookBinary looks for the binary in the PATH and in the directories of the
+// Postgres packages, which are not in the PATH of some distributions.
+func lookBinary(name string) (string, error) {
+	if path, err := exec.LookPath(name); err == nil {
+		return path, nil
+	}
+
+	matches, _ := filepath.Glob(filepath.Join("/usr/lib/postgresql/*/bin", name))
+
+	if len(matches) == 0 {
+		return "", fmt.Errorf("%s binary not found", name)
+	}
+
+	return matches[len(matches)-1], nil
+}
+
+func run(name string, args ...string) error {
+	if output, err := exec.Command(name, args...).CombinedOutput(); err != nil {
+		return fmt.Errorf("%s: %v: %s", filepath.Base(name), err, output)
+	}
+
+	return nil
+}
+
+// ping waits for the server to accept connections.
+func ping(db *sql.DB) error {
+	var (
+		err      error
+		deadline = time.Now().Add(30 * time.Second)
+	)
+
+	for time.Now().Before(deadline) {
+		if err = db.Ping(); err == nil {
+			return nil
+		}
+
+		time.Sleep(100 * time.Millisecond)
+	}
+
+	return err
+}
@@ -24,7 +24,8 @@ type config struct {
 
 // hooks per client, for fast access.
 type hooks struct {
-	Product []ent.Hook
+	Category []ent.Hook
+	Product  []ent.Hook
 }
 
 // Options applies the options on the config object.
@@ -0,0 +1,24 @@
+//go:build ignore
+// +build ignore
+
+package main
+
+import (
+	"log"
+
+	"github.com/facebookincubator/ent/entc"
+	"github.com/facebookincubator/ent/entc/gen"
+	"github.com/facebookincubator/ent/schema/field"
+)
+
+func main() {
+	config := &gen.Config{
+		IDType: &field.TypeInfo{Type: field.TypeInt},
+	}
+
+	// the glob skips the golden files of the template tests, which are
+	// parsed by the template directory option as templates
+	if err := entc.Generate("./schema", config, entc.TemplateGlob("../../template/*.tmpl")); err != nil {
+		log.Fatalf("running ent codegen: %v", err)
+	}
+}
@@ -1,3 +1,3 @@
 package ent
 
-//go:generate go run github.com/facebookincubator/ent/cmd/entc generate ./schema --template=../../template
+//go:generate go run entc.go
@@ -3,68 +3,1180 @@
 package ent
 
 import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
 	"encoding/base64"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"reflect"
 	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
 
+	"github.com/facebookincubator/ent/dialect"
 	"github.com/facebookincubator/ent/dialect/sql"
+	"github.com/google/uuid"
+	"github.com/phogolabs/ent/integration/ent/category"
+	"github.com/phogolabs/ent/integration/ent/product"
+	"golang.org/x/xerrors"
 )
 
-// Predicate creates a predicate
-type Predicate = func(s *sql.Selector)
+// CursorPosition represets a cursor position
+type CursorPosition struct {
+	OrderTerm
+	Value interface{}
+	// valued reports whether the value was set, since NULL is a valid one.
+	valued bool
+	// edge is the unique edge that holds the column, if any.
+	edge *cursorEdge
+	// score is the full-text search ranked by the virtual '_score' column, if any.
+	score *textSearch
+}
+
+// cursorEdge represents a unique edge whose field is selected by a cursor.
+type cursorEdge struct {
+	// name of the edge, used as an alias of its table in the subquery.
+	name string
+	// table of the edge.
+	table string
+	// field of the edge table that is used as a position column.
+	field string
+	// from is the column of the node table that is matched by the column to.
+	from string
+	// to is the column of the edge table that is matched by the column from.
+	to string
+}
+
+// column returns the correlated subquery that selects the field of the edge for
+// the items of the selector. Its value is NULL for the items without edge.
+func (e *cursorEdge) column(s *sql.Selector) string {
+	var (
+		b     = &sql.Builder{}
+		table = sql.Dialect(s.Dialect()).Table(e.table).As(e.name)
+	)
+
+	b.SetDialect(s.Dialect())
+
+	return "(SELECT " + table.C(e.field) +
+		" FROM " + b.Quote(e.table) + " AS " + b.Quote(e.name) +
+		" WHERE " + table.C(e.to) + " = " + s.C(e.from) + ")"
+}
+
+func (p *CursorPosition) reverse() *CursorPosition {
+	position := &CursorPosition{
+		OrderTerm: p.OrderTerm,
+		Value:     p.Value,
+		valued:    p.valued,
+		edge:      p.edge,
+		score:     p.score,
+	}
+
+	switch p.Direction {
+	case "+":
+		position.Direction = "-"
+	case "-":
+		position.Direction = "+"
+	}
+
+	switch p.Nulls {
+	case NullsFirst:
+		position.Nulls = NullsLast
+	case NullsLast:
+		position.Nulls = NullsFirst
+	}
+
+	return position
+}
+
+// nullable sorts the NULL values as the greatest ones, unless the order is given.
+func (p *CursorPosition) nullable() {
+	if p.Nulls != "" {
+		return
+	}
+
+	switch p.Direction {
+	case "+":
+		p.Nulls = NullsLast
+	case "-":
+		p.Nulls = NullsFirst
+	}
+}
+
+// column returns the qualified column of the position in the given selector.
+func (p *CursorPosition) column(s *sql.Selector) string {
+	switch {
+	case p.score != nil:
+		return p.score.rank(s.Dialect(), s.C)
+	case p.edge != nil:
+		return p.edge.column(s)
+	default:
+		return s.C(p.Column)
+	}
+}
+
+// expr returns the column of the position wrapped by its text modifiers.
+func (p *CursorPosition) expr(s *sql.Selector) string {
+	expr := p.column(s)
+
+	if p.Fold {
+		expr = "LOWER(" + expr + ")"
+	}
+
+	if p.Collation != "" {
+		b := &sql.Builder{}
+		b.SetDialect(s.Dialect())
+		expr += " COLLATE " + b.Quote(p.Collation)
+	}
+
+	return expr
+}
+
+// textual reports whether the position has a modifier of the text columns.
+func (p *CursorPosition) textual() bool {
+	return p.Fold || p.Collation != ""
+}
+
+// scalar returns an error if the position has a modifier of the text columns.
+func (p *CursorPosition) scalar() error {
+	if p.textual() {
+		return fmt.Errorf("ent: '%s' column does not support text modifiers", p.Column)
+	}
+
+	return nil
+}
+
+// quoteLiteral quotes the text as a string literal of the dialect.
+func quoteLiteral(d, text string) string {
+	text = strings.ReplaceAll(text, "\x00", "")
+
+	switch d {
+	case dialect.Postgres:
+		// the escape string literal does not depend on standard_conforming_strings
+		return "E'" + strings.NewReplacer(`\`, `\\`, `'`, `''`).Replace(text) + "'"
+	case dialect.MySQL:
+		// the hexadecimal literal does not depend on the NO_BACKSLASH_ESCAPES mode,
+		// and the introducer makes it a text of the charset of the tables
+		return "_utf8mb4 X'" + hex.EncodeToString([]byte(text)) + "'"
+	default:
+		return "'" + strings.ReplaceAll(text, "'", "''") + "'"
+	}
+}
+
+// predicate compares the expression of the position with its value by the given
+// operator (e.g. sql.GT), so the seek and the order of the position are consistent.
+func (p *CursorPosition) predicate(s *sql.Selector, op func(string, interface{}) *sql.Predicate) *sql.Predicate {
+	return op(p.expr(s), p.arg(s))
+}
+
+// arg returns the value of the position wrapped by its text modifiers, which is
+// folded by the database like the column. The value of the '_score' column is the
+// identifier of the item, whose rank is selected again.
+func (p *CursorPosition) arg(s *sql.Selector) interface{} {
+	d := s.Dialect()
+
+	switch {
+	case p.score != nil:
+		var (
+			b     = &sql.Builder{}
+			table = sql.Dialect(d).Table(p.score.table).As("seek_score")
+		)
+
+		b.SetDialect(d)
+
+		return sql.Raw("(SELECT " + p.score.rank(d, table.C) +
+			" FROM " + b.Quote(p.score.table) + " AS " + b.Quote("seek_score") +
+			" WHERE " + table.C(p.score.id) + " = " + quoteLiteral(d, fmt.Sprint(p.Value)) + ")")
+	case p.Fold:
+		return sql.Raw("LOWER(" + quoteLiteral(d, fmt.Sprint(p.Value)) + ")")
+	default:
+		return p.Value
+	}
+}
+
+func (p *CursorPosition) equal() Predicate {
+	return func(s *sql.Selector) {
+		if p.Value == nil {
+			s.Where(sql.IsNull(p.column(s)))
+		} else {
+			s.Where(p.predicate(s, sql.EQ))
+		}
+	}
+}
+
+func (p *CursorPosition) compare() Predicate {
+	op := sql.GT
+
+	if p.Direction == "-" {
+		op = sql.LT
+	}
+
+	return func(s *sql.Selector) {
+		column := p.column(s)
+
+		switch {
+		case p.Value == nil && p.Nulls == NullsFirst:
+			s.Where(sql.NotNull(column))
+		case p.Value == nil:
+			s.Where(sql.False())
+		case p.Nulls == NullsLast:
+			s.Where(sql.Or(p.predicate(s, op), sql.IsNull(column)))
+		default:
+			s.Where(p.predicate(s, op))
+		}
+	}
+}
+
+func (p *CursorPosition) orderBy() Order {
+	return func(s *sql.Selector) {
+		s.OrderBy(p.terms(s)...)
+	}
+}
+
+func (p *CursorPosition) terms(s *sql.Selector) []string {
+	var (
+		terms  = []string{}
+		column = p.column(s)
+		expr   = p.expr(s)
+	)
+
+	// NULLS FIRST and NULLS LAST are not supported by all dialects
+	// and that's why the NULL values are ordered by an expression.
+	switch p.Nulls {
+	case NullsFirst:
+		terms = append(terms, "("+column+" IS NULL) DESC")
+	case NullsLast:
+		terms = append(terms, "("+column+" IS NULL) ASC")
+	}
+
+	switch p.Direction {
+	case "+":
+		terms = append(terms, expr+" ASC")
+	case "-":
+		terms = append(terms, expr+" DESC")
+	}
+
+	return terms
+}
+
+// cursorComparable reports whether the positions can be compared as row values,
+// which requires the same direction and non-NULL values for all of them.
+func cursorComparable(positions []*CursorPosition) bool {
+	if len(positions) < 2 {
+		return false
+	}
+
+	for _, position := range positions {
+		switch {
+		case !position.valued, position.Value == nil, position.Nulls != "":
+			return false
+		case position.Direction != positions[0].Direction:
+			return false
+		}
+	}
+
+	return true
+}
+
+// cursorCompare builds the row value comparison '(a, b) > (x, y)' of the positions,
+// which unlike its expansion can use an index of the columns.
+func cursorCompare(positions []*CursorPosition) Predicate {
+	op := sql.CompositeGT
+
+	if positions[0].Direction == "-" {
+		op = sql.CompositeLT
+	}
+
+	return func(s *sql.Selector) {
+		var (
+			columns = make([]string, len(positions))
+			args    = make([]interface{}, len(positions))
+		)
+
+		for index, position := range positions {
+			columns[index] = position.expr(s)
+			args[index] = position.arg(s)
+		}
+
+		s.Where(op(columns, args...))
+	}
+}
+
+// serverVersions caches the versions of the database servers by their drivers.
+var serverVersions sync.Map
+
+// serverVersion represents the version of a database server.
+type serverVersion struct {
+	dialect string
+	// mariadb reports whether the MySQL server is a MariaDB one.
+	mariadb bool
+	major   int
+	minor   int
+}
+
+// atLeast reports whether the version is the given one or a later one.
+func (v *serverVersion) atLeast(major, minor int) bool {
+	return v.major > major || v.major == major && v.minor >= minor
+}
+
+// windowed reports whether the server supports the window functions, which
+// are available since SQLite 3.25, MySQL 8.0 and MariaDB 10.2.
+func (v *serverVersion) windowed() bool {
+	switch {
+	case v.dialect == dialect.SQLite:
+		return v.atLeast(3, 25)
+	case v.mariadb:
+		return v.atLeast(10, 2)
+	default:
+		return v.atLeast(8, 0)
+	}
+}
+
+// String returns the name and the number of the version.
+func (v *serverVersion) String() string {
+	name := v.dialect
+
+	if v.mariadb {
+		name = "mariadb"
+	}
+
+	return fmt.Sprintf("%s %d.%d", name, v.major, v.minor)
+}
+
+// serverDriver returns the driver of the database server, which is shared
+// by the debug drivers and the transactions of a client.
+func serverDriver(drv dialect.Driver) dialect.Driver {
+	for {
+		switch d := drv.(type) {
+		case *txDriver:
+			drv = d.drv
+		case *dialect.DebugDriver:
+			drv = d.Driver
+		default:
+			return drv
+		}
+	}
+}
+
+// loadServerVersion reads the version of the SQLite and MySQL servers once for
+// each driver, since their features depend on it. It returns nil for the other
+// dialects.
+func loadServerVersion(ctx context.Context, drv dialect.Driver) (*serverVersion, error) {
+	if version := cachedServerVersion(drv); version != nil {
+		return version, nil
+	}
+
+	var query string
+
+	switch drv.Dialect() {
+	case dialect.SQLite:
+		query = "SELECT sqlite_version()"
+	case dialect.MySQL:
+		query = "SELECT VERSION()"
+	default:
+		return nil, nil
+	}
+
+	rows := &sql.Rows{}
+
+	if err := drv.Query(ctx, query, []interface{}{}, rows); err != nil {
+		return nil, fmt.Errorf("ent: read server version: %v", err)
+	}
+	defer rows.Close()
+
+	var (
+		text    string
+		version = &serverVersion{dialect: drv.Dialect()}
+	)
+
+	if !rows.Next() {
+		return nil, fmt.Errorf("ent: server has no version")
+	}
+
+	if err := rows.Scan(&text); err != nil {
+		return nil, fmt.Errorf("ent: read server version: %v", err)
+	}
+
+	if _, err := fmt.Sscanf(text, "%d.%d", &version.major, &version.minor); err != nil {
+		return nil, fmt.Errorf("ent: invalid server version '%s'", text)
+	}
+
+	version.mariadb = strings.Contains(strings.ToLower(text), "mariadb")
+
+	serverVersions.Store(serverDriver(drv), version)
+	return version, nil
+}
+
+// cachedServerVersion returns the version of the server of the driver, or nil
+// if it was not read yet.
+func cachedServerVersion(drv dialect.Driver) *serverVersion {
+	if version, ok := serverVersions.Load(serverDriver(drv)); ok {
+		return version.(*serverVersion)
+	}
+
+	return nil
+}
+
+// cursorIndexed reports whether the columns of the positions are a prefix of the
+// columns of an ordering, which has a supporting index. The index is scanned forwards
+// when the directions of the positions are the ones of the ordering, and backwards
+// when all of them are the opposite.
+func cursorIndexed(positions []*CursorPosition, orderings ...string) bool {
+	if len(positions) == 0 {
+		return true
+	}
+
+	for _, ordering := range orderings {
+		terms, err := ParseOrder(ordering)
+
+		if err != nil || len(terms) < len(positions) {
+			continue
+		}
+
+		var (
+			prefix   = true
+			forward  = true
+			backward = true
+		)
+
+		for index, position := range positions {
+			term := terms[index]
+
+			if position.edge != nil || position.textual() || position.Column != term.Column {
+				prefix = false
+				break
+			}
+
+			forward = forward && position.Direction == term.Direction
+			backward = backward && position.Direction != term.Direction
+		}
+
+		if prefix && (forward || backward) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// cursorVersion is the version of the cursor token format.
+const cursorVersion = 1
+
+// cursorToken represents the content of a cursor token.
+type cursorToken struct {
+	// Version of the token format.
+	Version int `json:"v"`
+	// Order the token was produced with.
+	Order string `json:"o"`
+	// Values of the cursor positions.
+	Values []json.RawMessage `json:"p"`
+}
+
+// CursorMismatchError returns when a cursor token is decoded with an order
+// that is different from the one the token was produced with.
+type CursorMismatchError struct {
+	// Order is the requested order.
+	Order string
+	// Cursor is the order of the cursor token.
+	Cursor string
+}
+
+// Error implements the error interface.
+func (e *CursorMismatchError) Error() string {
+	return fmt.Sprintf("ent: cursor order '%s' does not match '%s'", e.Cursor, e.Order)
+}
+
+// IsCursorMismatch returns a boolean indicating whether the error is a cursor mismatch error.
+func IsCursorMismatch(err error) bool {
+	if err == nil {
+		return false
+	}
+	var e *CursorMismatchError
+	return xerrors.As(err, &e)
+}
+
+// UnsortableColumnError returns when a cursor order has a column that
+// is not marked as sortable by the pagination tag of the schema.
+type UnsortableColumnError struct {
+	// Column is the unsortable column.
+	Column string
+}
+
+// Error implements the error interface.
+func (e *UnsortableColumnError) Error() string {
+	return fmt.Sprintf("ent: column '%s' is not sortable", e.Column)
+}
+
+// IsUnsortableColumn returns a boolean indicating whether the error is an unsortable column error.
+func IsUnsortableColumn(err error) bool {
+	if err == nil {
+		return false
+	}
+	var e *UnsortableColumnError
+	return xerrors.As(err, &e)
+}
+
+// CursorOption configures the decoding of a cursor.
+type CursorOption func(*cursorOptions)
+
+type cursorOptions struct {
+	tiebreak bool
+	search   string
+	// searched reports whether the search query was set, since an empty one
+	// is a valid query that matches no items.
+	searched bool
+}
+
+// WithoutTiebreaker disables the identifier that is appended as a last column
+// to the orders which do not contain a unique column.
+func WithoutTiebreaker() CursorOption {
+	return func(options *cursorOptions) {
+		options.tiebreak = false
+	}
+}
+
+// WithSearch sets the full-text search query ranked by the virtual '_score'
+// column (e.g. "-_score,+id"). It has to match the query of the Search method.
+func WithSearch(query string) CursorOption {
+	return func(options *cursorOptions) {
+		options.search = query
+		options.searched = true
+	}
+}
+
+// CursorKey represents a key used to sign and optionally encrypt cursor tokens.
+type CursorKey struct {
+	// ID identifies the key in the cursor tokens.
+	ID string
+	// Secret is used to sign the cursor tokens with HMAC-SHA256.
+	Secret []byte
+	// Cipher is an optional AES key (16, 24 or 32 bytes long) used to
+	// seal the cursor tokens with AES-GCM.
+	Cipher []byte
+}
+
+// CursorSignatureError returns when a cursor token has an invalid signature
+// or it was signed with an unknown key.
+type CursorSignatureError struct {
+	// Key is the key id of the cursor token.
+	Key string
+}
+
+// Error implements the error interface.
+func (e *CursorSignatureError) Error() string {
+	return "ent: invalid pagination cursor signature"
+}
+
+// IsCursorSignatureError returns a boolean indicating whether the error is a cursor signature error.
+func IsCursorSignatureError(err error) bool {
+	if err == nil {
+		return false
+	}
+	var e *CursorSignatureError
+	return xerrors.As(err, &e)
+}
+
+// CursorCodec signs and optionally encrypts the cursor tokens.
+type CursorCodec struct {
+	keys []*cursorKey
+}
+
+type cursorKey struct {
+	*CursorKey
+	aead cipher.AEAD
+}
+
+// cursorCodec holds the *CursorCodec used by all cursors, which can be set
+// while the cursors are encoded and decoded by other goroutines.
+var cursorCodec atomic.Value
+
+// SetCursorCodec sets the codec used by all cursors. The cursor tokens are
+// neither signed nor encrypted if the codec is nil.
+func SetCursorCodec(codec *CursorCodec) {
+	cursorCodec.Store(codec)
+}
+
+// loadCursorCodec returns the codec used by all cursors, or nil if it is not set.
+func loadCursorCodec() *CursorCodec {
+	codec, _ := cursorCodec.Load().(*CursorCodec)
+	return codec
+}
+
+// NewCursorCodec creates a new codec for the given keys. The first key is used
+// to sign the new tokens, while all of them are used to verify the existing ones.
+func NewCursorCodec(keys ...*CursorKey) (*CursorCodec, error) {
+	codec := &CursorCodec{}
+
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("ent: cursor codec requires at least one key")
+	}
+
+	for _, key := range keys {
+		if key.ID == "" || strings.Contains(key.ID, ".") {
+			return nil, fmt.Errorf("ent: invalid cursor key id '%s'", key.ID)
+		}
+
+		if len(key.Secret) == 0 {
+			return nil, fmt.Errorf("ent: cursor key '%s' has no secret", key.ID)
+		}
+
+		item := &cursorKey{CursorKey: key}
+
+		if len(key.Cipher) > 0 {
+			block, err := aes.NewCipher(key.Cipher)
+			if err != nil {
+				return nil, fmt.Errorf("ent: invalid cursor key '%s' cipher: %v", key.ID, err)
+			}
+
+			if item.aead, err = cipher.NewGCM(block); err != nil {
+				return nil, err
+			}
+		}
+
+		codec.keys = append(codec.keys, item)
+	}
+
+	return codec, nil
+}
+
+func (c *CursorCodec) encode(data []byte) string {
+	key := c.keys[0]
+
+	if key.aead != nil {
+		nonce := make([]byte, key.aead.NonceSize())
+
+		if _, err := rand.Read(nonce); err != nil {
+			panic(err)
+		}
+
+		data = key.aead.Seal(nonce, nonce, data, []byte(key.ID))
+	}
+
+	message := key.ID + "." + base64.RawURLEncoding.EncodeToString(data)
+	return message + "." + base64.RawURLEncoding.EncodeToString(key.sign(message))
+}
+
+func (c *CursorCodec) decode(token string) ([]byte, error) {
+	parts := strings.Split(token, ".")
+
+	if len(parts) != 3 {
+		return nil, &CursorSignatureError{}
+	}
+
+	key := c.key(parts[0])
+
+	if key == nil {
+		return nil, &CursorSignatureError{Key: parts[0]}
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, &CursorSignatureError{Key: key.ID}
+	}
+
+	if !hmac.Equal(signature, key.sign(parts[0]+"."+parts[1])) {
+		return nil, &CursorSignatureError{Key: key.ID}
+	}
+
+	data, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return nil, err
+	}
+
+	if key.aead != nil {
+		size := key.aead.NonceSize()
+
+		if len(data) < size {
+			return nil, fmt.Errorf("ent: invalid pagination cursor")
+		}
+
+		if data, err = key.aead.Open(nil, data[:size], data[size:], []byte(key.ID)); err != nil {
+			return nil, fmt.Errorf("ent: invalid pagination cursor")
+		}
+	}
+
+	return data, nil
+}
+
+func (c *CursorCodec) key(id string) *cursorKey {
+	for _, key := range c.keys {
+		if key.ID == id {
+			return key
+		}
+	}
+
+	return nil
+}
+
+func (k *cursorKey) sign(message string) []byte {
+	mac := hmac.New(sha256.New, k.Secret)
+	mac.Write([]byte(message))
+	return mac.Sum(nil)
+}
+
+func encodeCursorToken(order string, values []interface{}) string {
+	token := &cursorToken{
+		Version: cursorVersion,
+		Order:   order,
+		Values:  make([]json.RawMessage, len(values)),
+	}
+
+	for index, value := range values {
+		data, err := json.Marshal(value)
+		if err != nil {
+			panic(err)
+		}
+
+		token.Values[index] = data
+	}
+
+	data, err := json.Marshal(token)
+	if err != nil {
+		panic(err)
+	}
+
+	if codec := loadCursorCodec(); codec != nil {
+		return codec.encode(data)
+	}
+
+	return strings.TrimRight(base64.URLEncoding.EncodeToString(data), "=")
+}
+
+func decodeCursorToken(value string) (*cursorToken, error) {
+	var (
+		token = &cursorToken{}
+		data  []byte
+		err   error
+	)
+
+	if codec := loadCursorCodec(); codec != nil {
+		data, err = codec.decode(value)
+	} else {
+		if n := len(value) % 4; n != 0 {
+			value += strings.Repeat("=", 4-n)
+		}
+
+		data, err = base64.URLEncoding.DecodeString(value)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(data, token); err != nil {
+		return nil, err
+	}
+
+	if token.Version != cursorVersion {
+		return nil, fmt.Errorf("ent: unsupported pagination cursor version %d", token.Version)
+	}
+
+	return token, nil
+}
+
+// CategoryCursor represents the cursor
+type CategoryCursor struct {
+	positions []*CursorPosition
+}
+
+// DecodeCategoryCursor decodes a cursor from its base-64 string representation.
+// It returns a *CursorMismatchError if the token was produced with another order.
+// The identifier is appended to the order if it does not contain a unique column,
+// in the direction of the last column.
+func DecodeCategoryCursor(order, token string, opts ...CursorOption) (*CategoryCursor, error) {
+	var (
+		cursor  = &CategoryCursor{}
+		options = &cursorOptions{tiebreak: true}
+	)
+
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	if err := cursor.positionsAt(order, options); err != nil {
+		return nil, err
+	}
+
+	if token == "" {
+		return cursor, nil
+	}
+
+	data, err := decodeCursorToken(token)
+	if err != nil {
+		return nil, err
+	}
+
+	if expected := cursor.order(); data.Order != expected {
+		return nil, &CursorMismatchError{Order: expected, Cursor: data.Order}
+	}
+
+	if err := cursor.valuesAt(data.Values); err != nil {
+		return nil, err
+	}
+
+	return cursor, nil
+}
+
+// DecodeCategoryCursorToken decodes a cursor from its base-64 string representation
+// by using the order the token was produced with. The identifier is not appended,
+// since the order of the token already has it.
+func DecodeCategoryCursorToken(token string, opts ...CursorOption) (*CategoryCursor, error) {
+	var (
+		cursor  = &CategoryCursor{}
+		options = &cursorOptions{}
+	)
+
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	if token == "" {
+		return cursor, nil
+	}
+
+	data, err := decodeCursorToken(token)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := cursor.positionsAt(data.Order, options); err != nil {
+		return nil, err
+	}
+
+	if err := cursor.valuesAt(data.Values); err != nil {
+		return nil, err
+	}
+
+	return cursor, nil
+}
+
+// String returns a base-64 string representation of a cursor.
+func (c *CategoryCursor) String() string {
+	count := len(c.positions)
+
+	if count == 0 {
+		return ""
+	}
+
+	values := make([]interface{}, count)
+
+	for index, position := range c.positions {
+		values[index] = position.Value
+	}
+
+	return encodeCursorToken(c.order(), values)
+}
+
+// Next returns the next cursor. It returns an error if the cursor orders by an
+// edge that was not eager-loaded for the last item.
+func (c *CategoryCursor) Next(input []*Category) (*CategoryCursor, error) {
+	count := len(input)
+
+	if count == 0 {
+		return &CategoryCursor{}, nil
+	}
+
+	return c.CursorFor(input[count-1])
+}
+
+// Prev returns the previous cursor. It returns an error if the cursor orders by
+// an edge that was not eager-loaded for the first item.
+func (c *CategoryCursor) Prev(input []*Category) (*CategoryCursor, error) {
+	if len(input) == 0 {
+		return &CategoryCursor{}, nil
+	}
+
+	return c.CursorFor(input[0])
+}
+
+// CursorFor returns a cursor that starts after the given item. It can be used
+// to resume the pagination from any item of a page. It returns an error if the
+// cursor orders by an edge that was not eager-loaded for the item.
+func (c *CategoryCursor) CursorFor(item *Category) (*CategoryCursor, error) {
+	cursor := &CategoryCursor{}
+
+	for _, position := range c.positions {
+		index := &CursorPosition{
+			OrderTerm: position.OrderTerm,
+			valued:    true,
+			edge:      position.edge,
+			score:     position.score,
+		}
+
+		switch position.Column {
+		case "id":
+			index.Value = item.ID
+		case "name":
+			index.Value = item.Name
+		}
+
+		cursor.positions = append(cursor.positions, index)
+	}
+
+	return cursor, nil
+}
+
+func (c *CategoryCursor) positionsAt(order string, options *cursorOptions) error {
+	terms, err := ParseOrder(order)
+	if err != nil {
+		return err
+	}
+
+	unique := false
+
+	for _, term := range terms {
+		position := &CursorPosition{OrderTerm: term}
+
+		switch position.Column {
+		case "id":
+			if err := position.scalar(); err != nil {
+				return err
+			}
+			unique = true
+		case "name":
+		default:
+			return fmt.Errorf("ent: unknown '%s' column", position.Column)
+		}
+		c.positions = append(c.positions, position)
+	}
 
-// EQ applies an equal predicate
-func EQ(field string, value interface{}) Predicate {
-	return func(s *sql.Selector) {
-		s.Where(sql.EQ(s.C(field), value))
+	// the identifier makes the order total, so no item is skipped. It takes the
+	// direction of the last term, so that the directions of the order match, and
+	// it can be seeked by a row value comparison and an index of the ordering.
+	if options.tiebreak && !unique {
+		direction := "+"
+
+		if count := len(c.positions); count > 0 {
+			direction = c.positions[count-1].Direction
+		}
+
+		c.positions = append(c.positions, &CursorPosition{
+			OrderTerm: OrderTerm{
+				Column:    "id",
+				Direction: direction,
+			},
+		})
 	}
+
+	return nil
 }
 
-// GT applies a greater than predicate
-func GT(field string, value interface{}) Predicate {
-	return func(s *sql.Selector) {
-		s.Where(sql.GT(s.C(field), value))
+func (c *CategoryCursor) order() string {
+	terms := make([]OrderTerm, len(c.positions))
+
+	for index, position := range c.positions {
+		terms[index] = position.OrderTerm
 	}
+
+	return FormatOrder(terms)
 }
 
-// LT applies a less than predicate
-func LT(field string, value interface{}) Predicate {
-	return func(s *sql.Selector) {
-		s.Where(sql.LT(s.C(field), value))
+func (c *CategoryCursor) valuesAt(values []json.RawMessage) error {
+	for index, position := range c.positions {
+		if index >= len(values) {
+			return fmt.Errorf("ent: invalid pagination cursor")
+		}
+
+		value, err := c.valueAt(position.Column, values[index])
+		if err != nil {
+			return err
+		}
+
+		position.Value = value
+		position.valued = true
 	}
+
+	return nil
 }
 
-// And groups list of predicates with the AND operator between them.
-func And(predicates ...Predicate) Predicate {
-	return func(s *sql.Selector) {
-		s1 := s.Clone().SetP(nil)
-		for _, p := range predicates {
-			p(s1)
-		}
-		s.Where(s1.P())
+func (c *CategoryCursor) valueAt(column string, data json.RawMessage) (interface{}, error) {
+	var value interface{}
+
+	if string(data) == "null" {
+		return nil, nil
+	}
+
+	switch column {
+	case "id":
+		value = new(int)
+	case "name":
+		value = new(string)
+	default:
+		value = new(interface{})
 	}
+
+	if err := json.Unmarshal(data, value); err != nil {
+		return nil, fmt.Errorf("ent: invalid '%s' cursor value: %v", column, err)
+	}
+
+	return reflect.ValueOf(value).Elem().Interface(), nil
+}
+
+// Seek seeks the query to a given cursor
+func (cq *CategoryQuery) Seek(cursor *CategoryCursor) *CategoryQuery {
+	return cq.seekTo(cursor.positions)
+}
+
+// SeekBefore seeks the query to the items before a given cursor. The items are
+// fetched in reverse order, so that the limit keeps the closest ones, and they
+// are returned in the order of the cursor.
+func (cq *CategoryQuery) SeekBefore(cursor *CategoryCursor) *CategoryQuery {
+	positions := make([]*CursorPosition, len(cursor.positions))
+
+	for index, position := range cursor.positions {
+		positions[index] = position.reverse()
+	}
+
+	cq.reversed = true
+
+	return cq.seekTo(positions)
+}
+
+func (cq *CategoryQuery) seekTo(positions []*CursorPosition) *CategoryQuery {
+	cq.seeks = append(cq.seeks, cq.seek(positions))
+
+	for _, position := range positions {
+		cq.order = append(cq.order, position.orderBy())
+	}
+
+	return cq
 }
 
-// Or groups list of predicates with the OR operator between them.
-func Or(predicates ...Predicate) Predicate {
+func (cq *CategoryQuery) seek(positions []*CursorPosition) Predicate {
+	expand := cq.seekExpand(positions)
+
+	if !cursorComparable(positions) {
+		return expand
+	}
+
+	cq.versioned = true
+
 	return func(s *sql.Selector) {
-		s1 := s.Clone().SetP(nil)
-		for i, p := range predicates {
-			if i > 0 {
-				s1.Or()
+		switch s.Dialect() {
+		case dialect.Postgres, dialect.MySQL:
+			cursorCompare(positions)(s)
+		case dialect.SQLite:
+			// the row values are supported since SQLite 3.15, and the
+			// comparison is expanded when the version was not read
+			if version := cachedServerVersion(cq.driver); version != nil && version.atLeast(3, 15) {
+				cursorCompare(positions)(s)
+			} else {
+				expand(s)
 			}
-			p(s1)
+		default:
+			expand(s)
 		}
-		s.Where(s1.P())
 	}
 }
 
-// CursorPosition represets a cursor position
-type CursorPosition struct {
-	Column    string
-	Direction string
-	Value     interface{}
+// prepare reads the version of the database server before the query, when
+// the predicates of its seek depend on it. It returns the error of the builder,
+// or an error if the server does not support its seek.
+func (cq *CategoryQuery) prepare(ctx context.Context) error {
+	if cq.err != nil {
+		return cq.err
+	}
+
+	if !cq.versioned && !cq.partitioned {
+		return nil
+	}
+
+	version, err := loadServerVersion(ctx, cq.driver)
+	if err != nil {
+		return err
+	}
+
+	if cq.partitioned && version != nil && !version.windowed() {
+		return fmt.Errorf("ent: seek of the eager-loaded edges requires window functions, which %s does not support", version)
+	}
+
+	return nil
+}
+
+// seekExpand builds the predicate 'a > x OR (a = x AND (b > y OR ...))' of the positions.
+func (cq *CategoryQuery) seekExpand(positions []*CursorPosition) Predicate {
+	// the cursor without values starts at the first item, and its predicate
+	// has no condition, which could not be grouped with the other ones
+	if len(positions) == 0 || !positions[0].valued {
+		return func(*sql.Selector) {}
+	}
+
+	var (
+		position  = positions[0]
+		predicate = position.compare()
+	)
+
+	if len(positions) > 1 {
+		predicate = Or(predicate,
+			And(position.equal(), cq.seekExpand(positions[1:])))
+	}
+
+	return predicate
+}
+
+// seekPartition seeks the query to the given positions and limits the number of
+// items in each partition of the column. It is used by the eager-loaded edges,
+// where the limit of the query is shared by all of their parents. The partitions
+// are numbered by the ROW_NUMBER window function, which is supported since
+// SQLite 3.25, MySQL 8.0 and MariaDB 10.2, and the query returns an error for the
+// earlier versions.
+func (cq *CategoryQuery) seekPartition(positions []*CursorPosition, column string, limit int) *CategoryQuery {
+	cq.seekTo(positions)
+	cq.partitioned = true
+
+	cq.predicates = append(cq.predicates, func(s *sql.Selector) {
+		var (
+			build = sql.Dialect(s.Dialect())
+			table = build.Table(category.Table)
+			terms = []string{}
+		)
+
+		for _, position := range positions {
+			terms = append(terms, position.terms(s)...)
+		}
+
+		window := "ROW_NUMBER() OVER (PARTITION BY " + table.C(column)
+
+		if len(terms) > 0 {
+			window += " ORDER BY " + strings.Join(terms, ", ")
+		}
+
+		window += ")"
+
+		partition := build.Select(table.C(category.FieldID), sql.As(window, "partition_position")).
+			From(table)
+
+		// the partitions contain only the items that match the query, whose
+		// predicate is cloned, since the query appends the next ones to it
+		if p := s.Clone().P(); p != nil {
+			partition.Where(p)
+		}
+
+		s.Where(sql.In(s.C(category.FieldID),
+			build.Select(category.FieldID).
+				From(partition.As("seek_partition")).
+				Where(sql.LTE("partition_position", limit))))
+	})
+
+	return cq
+}
+
+// WithProductsSeek eager-loads the "products" edge seeked to the given cursor,
+// with at most 'limit' items for each Category. The query returns an error
+// if the server does not support the window functions (e.g. SQLite before 3.25
+// or MySQL before 8.0).
+func (cq *CategoryQuery) WithProductsSeek(cursor *ProductCursor, limit int, opts ...func(*ProductQuery)) *CategoryQuery {
+	return cq.WithProducts(func(query *ProductQuery) {
+		for _, opt := range opts {
+			opt(query)
+		}
+		query.seekPartition(cursor.positions, category.ProductsColumn, limit)
+	})
 }
 
 // ProductCursor represents the cursor
@@ -72,15 +1184,71 @@ type ProductCursor struct {
 	positions []*CursorPosition
 }
 
-// DecodeCursor decodes a cursor from its base-64 string representation.
-func DecodeProductCursor(order, token string) (*ProductCursor, error) {
-	cursor := &ProductCursor{}
+// DecodeProductCursor decodes a cursor from its base-64 string representation.
+// It returns a *CursorMismatchError if the token was produced with another order.
+// The identifier is appended to the order if it does not contain a unique column,
+// in the direction of the last column.
+func DecodeProductCursor(order, token string, opts ...CursorOption) (*ProductCursor, error) {
+	var (
+		cursor  = &ProductCursor{}
+		options = &cursorOptions{tiebreak: true}
+	)
+
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	if err := cursor.positionsAt(order, options); err != nil {
+		return nil, err
+	}
+
+	if token == "" {
+		return cursor, nil
+	}
+
+	data, err := decodeCursorToken(token)
+	if err != nil {
+		return nil, err
+	}
+
+	if expected := cursor.order(); data.Order != expected {
+		return nil, &CursorMismatchError{Order: expected, Cursor: data.Order}
+	}
+
+	if err := cursor.valuesAt(data.Values); err != nil {
+		return nil, err
+	}
+
+	return cursor, nil
+}
+
+// DecodeProductCursorToken decodes a cursor from its base-64 string representation
+// by using the order the token was produced with. The identifier is not appended,
+// since the order of the token already has it.
+func DecodeProductCursorToken(token string, opts ...CursorOption) (*ProductCursor, error) {
+	var (
+		cursor  = &ProductCursor{}
+		options = &cursorOptions{}
+	)
+
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	if token == "" {
+		return cursor, nil
+	}
+
+	data, err := decodeCursorToken(token)
+	if err != nil {
+		return nil, err
+	}
 
-	if err := cursor.positionsAt(order); err != nil {
+	if err := cursor.positionsAt(data.Order, options); err != nil {
 		return nil, err
 	}
 
-	if err := cursor.valuesAt(token); err != nil {
+	if err := cursor.valuesAt(data.Values); err != nil {
 		return nil, err
 	}
 
@@ -101,177 +1269,397 @@ func (c *ProductCursor) String() string {
 		values[index] = position.Value
 	}
 
-	data, err := json.Marshal(values)
-	if err != nil {
-		panic(err)
-	}
-
-	return strings.TrimRight(base64.URLEncoding.EncodeToString(data), "=")
+	return encodeCursorToken(c.order(), values)
 }
 
-// Next returns the next cursor
-func (c *ProductCursor) Next(input []*Product) *ProductCursor {
-	var (
-		next  = ProductCursor{}
-		count = len(input)
-	)
+// Next returns the next cursor. It returns an error if the cursor orders by an
+// edge that was not eager-loaded for the last item.
+func (c *ProductCursor) Next(input []*Product) (*ProductCursor, error) {
+	count := len(input)
 
 	if count == 0 {
-		return &next
+		return &ProductCursor{}, nil
+	}
+
+	return c.CursorFor(input[count-1])
+}
+
+// Prev returns the previous cursor. It returns an error if the cursor orders by
+// an edge that was not eager-loaded for the first item.
+func (c *ProductCursor) Prev(input []*Product) (*ProductCursor, error) {
+	if len(input) == 0 {
+		return &ProductCursor{}, nil
 	}
 
-	item := input[count-1]
+	return c.CursorFor(input[0])
+}
+
+// CursorFor returns a cursor that starts after the given item. It can be used
+// to resume the pagination from any item of a page. It returns an error if the
+// cursor orders by an edge that was not eager-loaded for the item.
+func (c *ProductCursor) CursorFor(item *Product) (*ProductCursor, error) {
+	cursor := &ProductCursor{}
 
 	for _, position := range c.positions {
 		index := &CursorPosition{
-			Column:    position.Column,
-			Direction: position.Direction,
+			OrderTerm: position.OrderTerm,
+			valued:    true,
+			edge:      position.edge,
+			score:     position.score,
 		}
 
 		switch position.Column {
 		case "id":
 			index.Value = item.ID
+		case "deleted_at":
+			if item.DeletedAt != nil {
+				index.Value = *item.DeletedAt
+			}
 		case "title":
 			index.Value = item.Title
 		case "created_at":
 			index.Value = item.CreatedAt
 		case "updated_at":
 			index.Value = item.UpdatedAt
+		case "description":
+			if item.Description != nil {
+				index.Value = *item.Description
+			}
+		case "category.name":
+			edge, err := item.Edges.CategoryOrErr()
+			switch {
+			case IsNotFound(err):
+				// the items without edge have NULL values
+			case err != nil:
+				// the value of the edge is unknown, since it was not loaded
+				return nil, err
+			default:
+				index.Value = edge.Name
+			}
+		case "_score":
+			// the rank of the item is selected again by its identifier
+			index.Value = item.ID
 		}
 
-		next.positions = append(next.positions, index)
+		cursor.positions = append(cursor.positions, index)
 	}
 
-	return &next
+	return cursor, nil
 }
 
-func (c *ProductCursor) positionsAt(order string) error {
-	const (
-		separator = ","
-		asc       = "+"
-		desc      = "-"
-	)
-
-	for _, field := range strings.Split(order, separator) {
-		field = strings.TrimSpace(field)
-
-		if field == "" {
-			continue
-		}
+func (c *ProductCursor) positionsAt(order string, options *cursorOptions) error {
+	terms, err := ParseOrder(order)
+	if err != nil {
+		return err
+	}
 
-		position := &CursorPosition{
-			Column:    field,
-			Direction: asc,
-		}
+	unique := false
 
-		switch {
-		case strings.HasPrefix(field, asc):
-			position = &CursorPosition{
-				Column:    field[1:],
-				Direction: asc,
-			}
-		case strings.HasPrefix(field, desc):
-			position = &CursorPosition{
-				Column:    field[1:],
-				Direction: desc,
-			}
-		}
+	for _, term := range terms {
+		position := &CursorPosition{OrderTerm: term}
 
 		switch position.Column {
 		case "id":
+			if err := position.scalar(); err != nil {
+				return err
+			}
+			unique = true
+		case "deleted_at":
+			return &UnsortableColumnError{Column: position.Column}
 		case "title":
 		case "created_at":
+			if err := position.scalar(); err != nil {
+				return err
+			}
 		case "updated_at":
+			if err := position.scalar(); err != nil {
+				return err
+			}
+		case "description":
+			position.nullable()
+		case "category.name":
+			position.edge = &cursorEdge{
+				name:  "category",
+				table: category.Table,
+				field: category.FieldName,
+				from:  product.CategoryColumn,
+				to:    category.FieldID,
+			}
+			// the items without edge have NULL values
+			position.nullable()
+		case "_score":
+			if err := position.scalar(); err != nil {
+				return err
+			}
+			if !options.searched {
+				return fmt.Errorf("ent: '_score' column requires a search query")
+			}
+			position.score = newProductSearch(options.search)
 		default:
 			return fmt.Errorf("ent: unknown '%s' column", position.Column)
 		}
-
 		c.positions = append(c.positions, position)
 	}
 
-	return nil
-}
+	// the identifier makes the order total, so no item is skipped. It takes the
+	// direction of the last term, so that the directions of the order match, and
+	// it can be seeked by a row value comparison and an index of the ordering.
+	if options.tiebreak && !unique {
+		direction := "+"
 
-func (c *ProductCursor) valuesAt(token string) error {
-	values := []interface{}{}
+		if count := len(c.positions); count > 0 {
+			direction = c.positions[count-1].Direction
+		}
 
-	if token == "" {
-		return nil
+		c.positions = append(c.positions, &CursorPosition{
+			OrderTerm: OrderTerm{
+				Column:    "id",
+				Direction: direction,
+			},
+		})
 	}
 
-	if n := len(token) % 4; n != 0 {
-		token += strings.Repeat("=", 4-n)
-	}
+	return nil
+}
 
-	data, err := base64.URLEncoding.DecodeString(token)
-	if err != nil {
-		return err
-	}
+func (c *ProductCursor) order() string {
+	terms := make([]OrderTerm, len(c.positions))
 
-	if err := json.Unmarshal(data, &values); err != nil {
-		return err
+	for index, position := range c.positions {
+		terms[index] = position.OrderTerm
 	}
 
+	return FormatOrder(terms)
+}
+
+func (c *ProductCursor) valuesAt(values []json.RawMessage) error {
 	for index, position := range c.positions {
 		if index >= len(values) {
 			return fmt.Errorf("ent: invalid pagination cursor")
 		}
 
-		position.Value = values[index]
+		value, err := c.valueAt(position.Column, values[index])
+		if err != nil {
+			return err
+		}
+
+		position.Value = value
+		position.valued = true
 	}
 
 	return nil
 }
 
+func (c *ProductCursor) valueAt(column string, data json.RawMessage) (interface{}, error) {
+	var value interface{}
+
+	if string(data) == "null" {
+		return nil, nil
+	}
+
+	switch column {
+	case "id":
+		value = new(uuid.UUID)
+	case "deleted_at":
+		value = new(time.Time)
+	case "title":
+		value = new(string)
+	case "created_at":
+		value = new(time.Time)
+	case "updated_at":
+		value = new(time.Time)
+	case "description":
+		value = new(string)
+	case "category.name":
+		value = new(string)
+	case "_score":
+		value = new(uuid.UUID)
+	default:
+		value = new(interface{})
+	}
+
+	if err := json.Unmarshal(data, value); err != nil {
+		return nil, fmt.Errorf("ent: invalid '%s' cursor value: %v", column, err)
+	}
+
+	return reflect.ValueOf(value).Elem().Interface(), nil
+}
+
 // Seek seeks the query to a given cursor
 func (pq *ProductQuery) Seek(cursor *ProductCursor) *ProductQuery {
-	pq.predicates = append(pq.predicates, pq.seek(cursor.positions))
+	return pq.seekTo(cursor.positions)
+}
+
+// SeekBefore seeks the query to the items before a given cursor. The items are
+// fetched in reverse order, so that the limit keeps the closest ones, and they
+// are returned in the order of the cursor.
+func (pq *ProductQuery) SeekBefore(cursor *ProductCursor) *ProductQuery {
+	positions := make([]*CursorPosition, len(cursor.positions))
+
+	for index, position := range cursor.positions {
+		positions[index] = position.reverse()
+	}
+
+	pq.reversed = true
+
+	return pq.seekTo(positions)
+}
+
+func (pq *ProductQuery) seekTo(positions []*CursorPosition) *ProductQuery {
+	// the cursors of the items are built from the loaded "category" edge
+	for _, position := range positions {
+		if position.edge != nil && position.edge.name == "category" && pq.withCategory == nil {
+			pq.WithCategory()
+		}
+	}
+
+	// the '_score' column is ranked by the full-text search of the dialect
+	for _, position := range positions {
+		if position.score == nil {
+			continue
+		}
 
-	for _, position := range cursor.positions {
-		switch position.Direction {
-		case "+":
-			pq.order = append(pq.order, Asc(position.Column))
-		case "-":
-			pq.order = append(pq.order, Desc(position.Column))
+		if err := position.score.supported(pq.driver.Dialect()); err != nil {
+			pq.err = err
 		}
 	}
 
+	pq.warnIndex(positions)
+
+	pq.seeks = append(pq.seeks, pq.seek(positions))
+
+	for _, position := range positions {
+		pq.order = append(pq.order, position.orderBy())
+	}
+
 	return pq
 }
 
+// warnIndex logs in debug mode the orders that have no supporting index.
+func (pq *ProductQuery) warnIndex(positions []*CursorPosition) {
+	if !pq.debug || cursorIndexed(positions, "+id", "+title,+id", "-created_at,-id") {
+		return
+	}
+
+	cursor := &ProductCursor{positions: positions}
+	pq.log(fmt.Sprintf("ent: order '%s' of Product has no supporting index", cursor.order()))
+}
+
 func (pq *ProductQuery) seek(positions []*CursorPosition) Predicate {
-	var (
-		predicate        Predicate = func(*sql.Selector) {}
-		predicateCompare Predicate = func(*sql.Selector) {}
-		predicateEqual   Predicate = func(*sql.Selector) {}
-	)
+	expand := pq.seekExpand(positions)
 
-	if len(positions) == 0 {
-		return predicate
+	if !cursorComparable(positions) {
+		return expand
 	}
 
-	position := positions[0]
-
-	if position.Value != nil {
-		predicateEqual = EQ(position.Column, position.Value)
+	pq.versioned = true
 
-		switch position.Direction {
-		case "+":
-			predicateCompare = GT(position.Column, position.Value)
-		case "-":
-			predicateCompare = LT(position.Column, position.Value)
+	return func(s *sql.Selector) {
+		switch s.Dialect() {
+		case dialect.Postgres, dialect.MySQL:
+			cursorCompare(positions)(s)
+		case dialect.SQLite:
+			// the row values are supported since SQLite 3.15, and the
+			// comparison is expanded when the version was not read
+			if version := cachedServerVersion(pq.driver); version != nil && version.atLeast(3, 15) {
+				cursorCompare(positions)(s)
+			} else {
+				expand(s)
+			}
 		default:
-			predicateCompare = GT(position.Column, position.Value)
+			expand(s)
 		}
 	}
+}
+
+// prepare reads the version of the database server before the query, when
+// the predicates of its seek depend on it. It returns the error of the builder,
+// or an error if the server does not support its seek.
+func (pq *ProductQuery) prepare(ctx context.Context) error {
+	if pq.err != nil {
+		return pq.err
+	}
+
+	if !pq.versioned && !pq.partitioned {
+		return nil
+	}
+
+	version, err := loadServerVersion(ctx, pq.driver)
+	if err != nil {
+		return err
+	}
 
-	positions = positions[1:]
-	predicate = predicateCompare
+	if pq.partitioned && version != nil && !version.windowed() {
+		return fmt.Errorf("ent: seek of the eager-loaded edges requires window functions, which %s does not support", version)
+	}
+
+	return nil
+}
+
+// seekExpand builds the predicate 'a > x OR (a = x AND (b > y OR ...))' of the positions.
+func (pq *ProductQuery) seekExpand(positions []*CursorPosition) Predicate {
+	// the cursor without values starts at the first item, and its predicate
+	// has no condition, which could not be grouped with the other ones
+	if len(positions) == 0 || !positions[0].valued {
+		return func(*sql.Selector) {}
+	}
+
+	var (
+		position  = positions[0]
+		predicate = position.compare()
+	)
 
-	if len(positions) > 0 {
-		predicate = Or(predicateCompare,
-			And(predicateEqual, pq.seek(positions)))
+	if len(positions) > 1 {
+		predicate = Or(predicate,
+			And(position.equal(), pq.seekExpand(positions[1:])))
 	}
 
 	return predicate
 }
+
+// seekPartition seeks the query to the given positions and limits the number of
+// items in each partition of the column. It is used by the eager-loaded edges,
+// where the limit of the query is shared by all of their parents. The partitions
+// are numbered by the ROW_NUMBER window function, which is supported since
+// SQLite 3.25, MySQL 8.0 and MariaDB 10.2, and the query returns an error for the
+// earlier versions.
+func (pq *ProductQuery) seekPartition(positions []*CursorPosition, column string, limit int) *ProductQuery {
+	pq.seekTo(positions)
+	pq.partitioned = true
+
+	pq.predicates = append(pq.predicates, func(s *sql.Selector) {
+		var (
+			build = sql.Dialect(s.Dialect())
+			table = build.Table(product.Table)
+			terms = []string{}
+		)
+
+		for _, position := range positions {
+			terms = append(terms, position.terms(s)...)
+		}
+
+		window := "ROW_NUMBER() OVER (PARTITION BY " + table.C(column)
+
+		if len(terms) > 0 {
+			window += " ORDER BY " + strings.Join(terms, ", ")
+		}
+
+		window += ")"
+
+		partition := build.Select(table.C(product.FieldID), sql.As(window, "partition_position")).
+			From(table)
+
+		// the partitions contain only the items that match the query, whose
+		// predicate is cloned, since the query appends the next ones to it
+		if p := s.Clone().P(); p != nil {
+			partition.Where(p)
+		}
+
+		s.Where(sql.In(s.C(product.FieldID),
+			build.Select(product.FieldID).
+				From(partition.As("seek_partition")).
+				Where(sql.LTE("partition_position", limit))))
+	})
+
+	return pq
+}
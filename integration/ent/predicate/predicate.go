@@ -6,5 +6,8 @@ import (
 	"github.com/facebookincubator/ent/dialect/sql"
 )
 
+// Category is the predicate function for category builders.
+type Category func(*sql.Selector)
+
 // Product is the predicate function for product builders.
 type Product func(*sql.Selector)
@@ -8,30 +8,71 @@ import (
 	"time"
 
 	"github.com/facebookincubator/ent/dialect/sql"
-	"github.com/phogolabs/ent/integration/ent/product"
 	"github.com/google/uuid"
+	"github.com/phogolabs/ent/integration/ent/category"
+	"github.com/phogolabs/ent/integration/ent/product"
 )
 
 // Product is the model entity for the Product schema.
 type Product struct {
-	config `json:"-"`
+	config `json:"-" ordering:"-" pagination:"-"`
 	// ID of the ent.
 	ID uuid.UUID `json:"id,omitempty"`
+	// DeletedAt holds the value of the "deleted_at" field.
+	DeletedAt *time.Time `json:"deleted_at,omitempty"`
 	// Title holds the value of the "title" field.
-	Title string `json:"title,omitempty"`
+	Title string `json:"title,omitempty" pagination:"sortable,searchable" ordering:"+title,+id"`
 	// CreatedAt holds the value of the "created_at" field.
-	CreatedAt time.Time `json:"created_at,omitempty"`
+	CreatedAt time.Time `json:"created_at,omitempty" pagination:"sortable" ordering:"-created_at,-id"`
 	// UpdatedAt holds the value of the "updated_at" field.
-	UpdatedAt time.Time `json:"updated_at,omitempty"`
+	UpdatedAt time.Time `json:"updated_at,omitempty" pagination:"sortable"`
+	// Description holds the value of the "description" field.
+	Description *string `json:"description,omitempty" pagination:"sortable"`
+	// Edges holds the relations/edges for other nodes in the graph.
+	// The values are being populated by the ProductQuery when eager-loading is set.
+	Edges             ProductEdges `json:"edges"`
+	category_products *int
+}
+
+// ProductEdges holds the relations/edges for other nodes in the graph.
+type ProductEdges struct {
+	// Category holds the value of the category edge.
+	Category *Category `pagination:"sortable"`
+	// loadedTypes holds the information for reporting if a
+	// type was loaded (or requested) in eager-loading or not.
+	loadedTypes [1]bool
+}
+
+// CategoryOrErr returns the Category value or an error if the edge
+// was not loaded in eager-loading, or loaded but was not found.
+func (e ProductEdges) CategoryOrErr() (*Category, error) {
+	if e.loadedTypes[0] {
+		if e.Category == nil {
+			// The edge category was loaded in eager-loading,
+			// but was not found.
+			return nil, &NotFoundError{label: category.Label}
+		}
+		return e.Category, nil
+	}
+	return nil, &NotLoadedError{edge: "category"}
 }
 
 // scanValues returns the types for scanning values from sql.Rows.
 func (*Product) scanValues() []interface{} {
 	return []interface{}{
 		&uuid.UUID{},      // id
+		&sql.NullTime{},   // deleted_at
 		&sql.NullString{}, // title
 		&sql.NullTime{},   // created_at
 		&sql.NullTime{},   // updated_at
+		&sql.NullString{}, // description
+	}
+}
+
+// fkValues returns the types for scanning foreign-keys values from sql.Rows.
+func (*Product) fkValues() []interface{} {
+	return []interface{}{
+		&sql.NullInt64{}, // category_products
 	}
 }
 
@@ -47,24 +88,50 @@ func (pr *Product) assignValues(values ...interface{}) error {
 		pr.ID = *value
 	}
 	values = values[1:]
-	if value, ok := values[0].(*sql.NullString); !ok {
-		return fmt.Errorf("unexpected type %T for field title", values[0])
+	if value, ok := values[0].(*sql.NullTime); !ok {
+		return fmt.Errorf("unexpected type %T for field deleted_at", values[0])
+	} else if value.Valid {
+		pr.DeletedAt = new(time.Time)
+		*pr.DeletedAt = value.Time
+	}
+	if value, ok := values[1].(*sql.NullString); !ok {
+		return fmt.Errorf("unexpected type %T for field title", values[1])
 	} else if value.Valid {
 		pr.Title = value.String
 	}
-	if value, ok := values[1].(*sql.NullTime); !ok {
-		return fmt.Errorf("unexpected type %T for field created_at", values[1])
+	if value, ok := values[2].(*sql.NullTime); !ok {
+		return fmt.Errorf("unexpected type %T for field created_at", values[2])
 	} else if value.Valid {
 		pr.CreatedAt = value.Time
 	}
-	if value, ok := values[2].(*sql.NullTime); !ok {
-		return fmt.Errorf("unexpected type %T for field updated_at", values[2])
+	if value, ok := values[3].(*sql.NullTime); !ok {
+		return fmt.Errorf("unexpected type %T for field updated_at", values[3])
 	} else if value.Valid {
 		pr.UpdatedAt = value.Time
 	}
+	if value, ok := values[4].(*sql.NullString); !ok {
+		return fmt.Errorf("unexpected type %T for field description", values[4])
+	} else if value.Valid {
+		pr.Description = new(string)
+		*pr.Description = value.String
+	}
+	values = values[5:]
+	if len(values) == len(product.ForeignKeys) {
+		if value, ok := values[0].(*sql.NullInt64); !ok {
+			return fmt.Errorf("unexpected type %T for edge-field category_products", value)
+		} else if value.Valid {
+			pr.category_products = new(int)
+			*pr.category_products = int(value.Int64)
+		}
+	}
 	return nil
 }
 
+// QueryCategory queries the category edge of the Product.
+func (pr *Product) QueryCategory() *CategoryQuery {
+	return (&ProductClient{config: pr.config}).QueryCategory(pr)
+}
+
 // Update returns a builder for updating this Product.
 // Note that, you need to call Product.Unwrap() before calling this method, if this Product
 // was returned from a transaction, and the transaction was committed or rolled back.
@@ -88,12 +155,20 @@ func (pr *Product) String() string {
 	var builder strings.Builder
 	builder.WriteString("Product(")
 	builder.WriteString(fmt.Sprintf("id=%v", pr.ID))
+	if v := pr.DeletedAt; v != nil {
+		builder.WriteString(", deleted_at=")
+		builder.WriteString(v.Format(time.ANSIC))
+	}
 	builder.WriteString(", title=")
 	builder.WriteString(pr.Title)
 	builder.WriteString(", created_at=")
 	builder.WriteString(pr.CreatedAt.Format(time.ANSIC))
 	builder.WriteString(", updated_at=")
 	builder.WriteString(pr.UpdatedAt.Format(time.ANSIC))
+	if v := pr.Description; v != nil {
+		builder.WriteString(", description=")
+		builder.WriteString(*v)
+	}
 	builder.WriteByte(')')
 	return builder.String()
 }
@@ -10,21 +10,40 @@ const (
 	// Label holds the string label denoting the product type in the database.
 	Label = "product"
 	// FieldID holds the string denoting the id field in the database.
-	FieldID        = "id"         // FieldTitle holds the string denoting the title vertex property in the database.
-	FieldTitle     = "title"      // FieldCreatedAt holds the string denoting the created_at vertex property in the database.
-	FieldCreatedAt = "created_at" // FieldUpdatedAt holds the string denoting the updated_at vertex property in the database.
-	FieldUpdatedAt = "updated_at"
+	FieldID          = "id"         // FieldDeletedAt holds the string denoting the deleted_at vertex property in the database.
+	FieldDeletedAt   = "deleted_at" // FieldTitle holds the string denoting the title vertex property in the database.
+	FieldTitle       = "title"      // FieldCreatedAt holds the string denoting the created_at vertex property in the database.
+	FieldCreatedAt   = "created_at" // FieldUpdatedAt holds the string denoting the updated_at vertex property in the database.
+	FieldUpdatedAt   = "updated_at" // FieldDescription holds the string denoting the description vertex property in the database.
+	FieldDescription = "description"
+
+	// EdgeCategory holds the string denoting the category edge name in mutations.
+	EdgeCategory = "category"
 
 	// Table holds the table name of the product in the database.
 	Table = "products"
+	// CategoryTable is the table the holds the category relation/edge.
+	CategoryTable = "products"
+	// CategoryInverseTable is the table name for the Category entity.
+	// It exists in this package in order to avoid circular dependency with the "category" package.
+	CategoryInverseTable = "categories"
+	// CategoryColumn is the table column denoting the category relation/edge.
+	CategoryColumn = "category_products"
 )
 
 // Columns holds all SQL columns for product fields.
 var Columns = []string{
 	FieldID,
+	FieldDeletedAt,
 	FieldTitle,
 	FieldCreatedAt,
 	FieldUpdatedAt,
+	FieldDescription,
+}
+
+// ForeignKeys holds the SQL foreign-keys that are owned by the Product type.
+var ForeignKeys = []string{
+	"category_products",
 }
 
 var (
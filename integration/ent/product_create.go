@@ -10,8 +10,9 @@ import (
 
 	"github.com/facebookincubator/ent/dialect/sql/sqlgraph"
 	"github.com/facebookincubator/ent/schema/field"
-	"github.com/phogolabs/ent/integration/ent/product"
 	"github.com/google/uuid"
+	"github.com/phogolabs/ent/integration/ent/category"
+	"github.com/phogolabs/ent/integration/ent/product"
 )
 
 // ProductCreate is the builder for creating a Product entity.
@@ -21,6 +22,20 @@ type ProductCreate struct {
 	hooks    []Hook
 }
 
+// SetDeletedAt sets the deleted_at field.
+func (pc *ProductCreate) SetDeletedAt(t time.Time) *ProductCreate {
+	pc.mutation.SetDeletedAt(t)
+	return pc
+}
+
+// SetNillableDeletedAt sets the deleted_at field if the given value is not nil.
+func (pc *ProductCreate) SetNillableDeletedAt(t *time.Time) *ProductCreate {
+	if t != nil {
+		pc.SetDeletedAt(*t)
+	}
+	return pc
+}
+
 // SetTitle sets the title field.
 func (pc *ProductCreate) SetTitle(s string) *ProductCreate {
 	pc.mutation.SetTitle(s)
@@ -55,12 +70,45 @@ func (pc *ProductCreate) SetNillableUpdatedAt(t *time.Time) *ProductCreate {
 	return pc
 }
 
+// SetDescription sets the description field.
+func (pc *ProductCreate) SetDescription(s string) *ProductCreate {
+	pc.mutation.SetDescription(s)
+	return pc
+}
+
+// SetNillableDescription sets the description field if the given value is not nil.
+func (pc *ProductCreate) SetNillableDescription(s *string) *ProductCreate {
+	if s != nil {
+		pc.SetDescription(*s)
+	}
+	return pc
+}
+
 // SetID sets the id field.
 func (pc *ProductCreate) SetID(u uuid.UUID) *ProductCreate {
 	pc.mutation.SetID(u)
 	return pc
 }
 
+// SetCategoryID sets the category edge to Category by id.
+func (pc *ProductCreate) SetCategoryID(id int) *ProductCreate {
+	pc.mutation.SetCategoryID(id)
+	return pc
+}
+
+// SetNillableCategoryID sets the category edge to Category by id if the given value is not nil.
+func (pc *ProductCreate) SetNillableCategoryID(id *int) *ProductCreate {
+	if id != nil {
+		pc = pc.SetCategoryID(*id)
+	}
+	return pc
+}
+
+// SetCategory sets the category edge to Category.
+func (pc *ProductCreate) SetCategory(c *Category) *ProductCreate {
+	return pc.SetCategoryID(c.ID)
+}
+
 // Save creates the Product in the database.
 func (pc *ProductCreate) Save(ctx context.Context) (*Product, error) {
 	if _, ok := pc.mutation.Title(); !ok {
@@ -129,6 +177,14 @@ func (pc *ProductCreate) sqlSave(ctx context.Context) (*Product, error) {
 		pr.ID = id
 		_spec.ID.Value = id
 	}
+	if value, ok := pc.mutation.DeletedAt(); ok {
+		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
+			Type:   field.TypeTime,
+			Value:  value,
+			Column: product.FieldDeletedAt,
+		})
+		pr.DeletedAt = &value
+	}
 	if value, ok := pc.mutation.Title(); ok {
 		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
 			Type:   field.TypeString,
@@ -153,6 +209,33 @@ func (pc *ProductCreate) sqlSave(ctx context.Context) (*Product, error) {
 		})
 		pr.UpdatedAt = value
 	}
+	if value, ok := pc.mutation.Description(); ok {
+		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: product.FieldDescription,
+		})
+		pr.Description = &value
+	}
+	if nodes := pc.mutation.CategoryIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   product.CategoryTable,
+			Columns: []string{product.CategoryColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: &sqlgraph.FieldSpec{
+					Type:   field.TypeInt,
+					Column: category.FieldID,
+				},
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges = append(_spec.Edges, edge)
+	}
 	if err := sqlgraph.CreateNode(ctx, pc.driver, _spec); err != nil {
 		if cerr, ok := isSQLConstraintError(err); ok {
 			err = cerr
@@ -5,6 +5,7 @@ package ent
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/facebookincubator/ent/dialect/sql"
 	"github.com/facebookincubator/ent/dialect/sql/sqlgraph"
@@ -19,6 +20,8 @@ type ProductDelete struct {
 	hooks      []Hook
 	mutation   *ProductMutation
 	predicates []predicate.Product
+	// hard reports whether the entities are removed instead of soft-deleted.
+	hard bool
 }
 
 // Where adds a new predicate to the delete builder.
@@ -65,6 +68,9 @@ func (pd *ProductDelete) ExecX(ctx context.Context) int {
 }
 
 func (pd *ProductDelete) sqlExec(ctx context.Context) (int, error) {
+	if !pd.hard {
+		return pd.sqlSoftExec(ctx)
+	}
 	_spec := &sqlgraph.DeleteSpec{
 		Node: &sqlgraph.NodeSpec{
 			Table: product.Table,
@@ -84,6 +90,33 @@ func (pd *ProductDelete) sqlExec(ctx context.Context) (int, error) {
 	return sqlgraph.DeleteNodes(ctx, pd.driver, _spec)
 }
 
+// sqlSoftExec sets the deleted_at field of the entities, which are not deleted yet.
+func (pd *ProductDelete) sqlSoftExec(ctx context.Context) (int, error) {
+	_spec := &sqlgraph.UpdateSpec{
+		Node: &sqlgraph.NodeSpec{
+			Table:   product.Table,
+			Columns: product.Columns,
+			ID: &sqlgraph.FieldSpec{
+				Type:   field.TypeUUID,
+				Column: product.FieldID,
+			},
+		},
+	}
+	// the items which are already deleted keep their time
+	ps := append([]predicate.Product{product.DeletedAtIsNil()}, pd.predicates...)
+	_spec.Predicate = func(selector *sql.Selector) {
+		for i := range ps {
+			ps[i](selector)
+		}
+	}
+	_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+		Type:   field.TypeTime,
+		Value:  time.Now(),
+		Column: product.FieldDeletedAt,
+	})
+	return sqlgraph.UpdateNodes(ctx, pd.driver, _spec)
+}
+
 // ProductDeleteOne is the builder for deleting a single Product entity.
 type ProductDeleteOne struct {
 	pd *ProductDelete
@@ -11,9 +11,10 @@ import (
 	"github.com/facebookincubator/ent/dialect/sql"
 	"github.com/facebookincubator/ent/dialect/sql/sqlgraph"
 	"github.com/facebookincubator/ent/schema/field"
+	"github.com/google/uuid"
+	"github.com/phogolabs/ent/integration/ent/category"
 	"github.com/phogolabs/ent/integration/ent/predicate"
 	"github.com/phogolabs/ent/integration/ent/product"
-	"github.com/google/uuid"
 )
 
 // ProductQuery is the builder for querying Product entities.
@@ -24,6 +25,26 @@ type ProductQuery struct {
 	order      []Order
 	unique     []string
 	predicates []predicate.Product
+	// eager-loading edges.
+	withCategory *CategoryQuery
+	withFKs      bool
+	// versioned reports whether the seek of the query depends on the
+	// version of the database server, which is read before the query.
+	versioned bool
+	// partitioned reports whether the query seeks the partitions of an
+	// eager-loaded edge, which requires the window functions.
+	partitioned bool
+	// err is the error of the builder, which is returned by the query.
+	err error
+	// seeks are the predicates of the cursors, which are kept apart from the
+	// filters of the query, so that its counts can drop them.
+	seeks []predicate.Product
+	// reversed reports whether the query is seeked before a cursor, whose
+	// items are fetched in reverse order and restored after the query.
+	reversed bool
+	// deleted is the scope of the soft-deleted items, which are excluded
+	// by default.
+	deleted deletedScope
 	// intermediate query.
 	sql *sql.Selector
 }
@@ -52,6 +73,18 @@ func (pq *ProductQuery) Order(o ...Order) *ProductQuery {
 	return pq
 }
 
+// QueryCategory chains the current query on the category edge.
+func (pq *ProductQuery) QueryCategory() *CategoryQuery {
+	query := &CategoryQuery{config: pq.config}
+	step := sqlgraph.NewStep(
+		sqlgraph.From(product.Table, product.FieldID, pq.sqlQuery()),
+		sqlgraph.To(category.Table, category.FieldID),
+		sqlgraph.Edge(sqlgraph.M2O, true, product.CategoryTable, product.CategoryColumn),
+	)
+	query.sql = sqlgraph.SetNeighbors(pq.driver.Dialect(), step)
+	return query
+}
+
 // First returns the first Product entity in the query. Returns *NotFoundError when no product was found.
 func (pq *ProductQuery) First(ctx context.Context) (*Product, error) {
 	prs, err := pq.Limit(1).All(ctx)
@@ -166,6 +199,11 @@ func (pq *ProductQuery) IDs(ctx context.Context) ([]uuid.UUID, error) {
 	if err := pq.Select(product.FieldID).Scan(ctx, &ids); err != nil {
 		return nil, err
 	}
+	if pq.reversed {
+		for i, j := 0, len(ids)-1; i < j; i, j = i+1, j-1 {
+			ids[i], ids[j] = ids[j], ids[i]
+		}
+	}
 	return ids, nil
 }
 
@@ -216,30 +254,54 @@ func (pq *ProductQuery) Clone() *ProductQuery {
 		order:      append([]Order{}, pq.order...),
 		unique:     append([]string{}, pq.unique...),
 		predicates: append([]predicate.Product{}, pq.predicates...),
+		// clone the state of the pagination.
+		versioned:   pq.versioned,
+		partitioned: pq.partitioned,
+		err:         pq.err,
+		seeks:       append([]predicate.Product{}, pq.seeks...),
+		reversed:    pq.reversed,
+		deleted:     pq.deleted,
 		// clone intermediate query.
 		sql: pq.sql.Clone(),
 	}
 }
 
+//	WithCategory tells the query-builder to eager-loads the nodes that are connected to
+//
+// the "category" edge. The optional arguments used to configure the query builder of the edge.
+func (pq *ProductQuery) WithCategory(opts ...func(*CategoryQuery)) *ProductQuery {
+	query := &CategoryQuery{config: pq.config}
+	for _, opt := range opts {
+		opt(query)
+	}
+	pq.withCategory = query
+	return pq
+}
+
 // GroupBy used to group vertices by one or more fields/columns.
 // It is often used with aggregate functions, like: count, max, mean, min, sum.
 //
 // Example:
 //
 //	var v []struct {
-//		Title string `json:"title,omitempty"`
+//		DeletedAt time.Time `json:"deleted_at,omitempty"`
 //		Count int `json:"count,omitempty"`
 //	}
 //
 //	client.Product.Query().
-//		GroupBy(product.FieldTitle).
+//		GroupBy(product.FieldDeletedAt).
 //		Aggregate(ent.Count()).
 //		Scan(ctx, &v)
 //
 func (pq *ProductQuery) GroupBy(field string, fields ...string) *ProductGroupBy {
 	group := &ProductGroupBy{config: pq.config}
 	group.fields = append([]string{field}, fields...)
-	group.sql = pq.sqlQuery()
+	group.path = func(ctx context.Context) (*sql.Selector, error) {
+		if err := pq.prepare(ctx); err != nil {
+			return nil, err
+		}
+		return pq.sqlQuery(), nil
+	}
 	return group
 }
 
@@ -248,29 +310,50 @@ func (pq *ProductQuery) GroupBy(field string, fields ...string) *ProductGroupBy
 // Example:
 //
 //	var v []struct {
-//		Title string `json:"title,omitempty"`
+//		DeletedAt time.Time `json:"deleted_at,omitempty"`
 //	}
 //
 //	client.Product.Query().
-//		Select(product.FieldTitle).
+//		Select(product.FieldDeletedAt).
 //		Scan(ctx, &v)
 //
 func (pq *ProductQuery) Select(field string, fields ...string) *ProductSelect {
 	selector := &ProductSelect{config: pq.config}
 	selector.fields = append([]string{field}, fields...)
-	selector.sql = pq.sqlQuery()
+	selector.path = func(ctx context.Context) (*sql.Selector, error) {
+		if err := pq.prepare(ctx); err != nil {
+			return nil, err
+		}
+		return pq.sqlQuery(), nil
+	}
 	return selector
 }
 
 func (pq *ProductQuery) sqlAll(ctx context.Context) ([]*Product, error) {
+	if err := pq.prepare(ctx); err != nil {
+		return nil, err
+	}
 	var (
-		nodes = []*Product{}
-		_spec = pq.querySpec()
+		nodes       = []*Product{}
+		withFKs     = pq.withFKs
+		_spec       = pq.querySpec()
+		loadedTypes = [1]bool{
+			pq.withCategory != nil,
+		}
 	)
+	if pq.withCategory != nil {
+		withFKs = true
+	}
+	if withFKs {
+		_spec.Node.Columns = append(_spec.Node.Columns, product.ForeignKeys...)
+	}
 	_spec.ScanValues = func() []interface{} {
 		node := &Product{config: pq.config}
 		nodes = append(nodes, node)
 		values := node.scanValues()
+		if withFKs {
+			values = append(values, node.fkValues()...)
+		}
 		return values
 	}
 	_spec.Assign = func(values ...interface{}) error {
@@ -278,6 +361,7 @@ func (pq *ProductQuery) sqlAll(ctx context.Context) ([]*Product, error) {
 			return fmt.Errorf("ent: Assign called without calling ScanValues")
 		}
 		node := nodes[len(nodes)-1]
+		node.Edges.loadedTypes = loadedTypes
 		return node.assignValues(values...)
 	}
 	if err := sqlgraph.QueryNodes(ctx, pq.driver, _spec); err != nil {
@@ -286,10 +370,44 @@ func (pq *ProductQuery) sqlAll(ctx context.Context) ([]*Product, error) {
 	if len(nodes) == 0 {
 		return nodes, nil
 	}
+
+	if query := pq.withCategory; query != nil {
+		ids := make([]int, 0, len(nodes))
+		nodeids := make(map[int][]*Product)
+		for i := range nodes {
+			if fk := nodes[i].category_products; fk != nil {
+				ids = append(ids, *fk)
+				nodeids[*fk] = append(nodeids[*fk], nodes[i])
+			}
+		}
+		query.Where(category.IDIn(ids...))
+		neighbors, err := query.All(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, n := range neighbors {
+			nodes, ok := nodeids[n.ID]
+			if !ok {
+				return nil, fmt.Errorf(`unexpected foreign-key "category_products" returned %v`, n.ID)
+			}
+			for i := range nodes {
+				nodes[i].Edges.Category = n
+			}
+		}
+	}
+
+	if pq.reversed {
+		for i, j := 0, len(nodes)-1; i < j; i, j = i+1, j-1 {
+			nodes[i], nodes[j] = nodes[j], nodes[i]
+		}
+	}
 	return nodes, nil
 }
 
 func (pq *ProductQuery) sqlCount(ctx context.Context) (int, error) {
+	if err := pq.prepare(ctx); err != nil {
+		return 0, err
+	}
 	_spec := pq.querySpec()
 	return sqlgraph.CountNodes(ctx, pq.driver, _spec)
 }
@@ -315,7 +433,7 @@ func (pq *ProductQuery) querySpec() *sqlgraph.QuerySpec {
 		From:   pq.sql,
 		Unique: true,
 	}
-	if ps := pq.predicates; len(ps) > 0 {
+	if ps := pq.scoped(); len(ps) > 0 {
 		_spec.Predicate = func(selector *sql.Selector) {
 			for i := range ps {
 				ps[i](selector)
@@ -346,7 +464,7 @@ func (pq *ProductQuery) sqlQuery() *sql.Selector {
 		selector = pq.sql
 		selector.Select(selector.Columns(product.Columns...)...)
 	}
-	for _, p := range pq.predicates {
+	for _, p := range pq.scoped() {
 		p(selector)
 	}
 	for _, p := range pq.order {
@@ -370,6 +488,8 @@ type ProductGroupBy struct {
 	fns    []Aggregate
 	// intermediate query.
 	sql *sql.Selector
+	// path returns the intermediate query, once the query is prepared.
+	path func(context.Context) (*sql.Selector, error)
 }
 
 // Aggregate adds the given aggregation functions to the group-by query.
@@ -380,6 +500,11 @@ func (pgb *ProductGroupBy) Aggregate(fns ...Aggregate) *ProductGroupBy {
 
 // Scan applies the group-by query and scan the result into the given value.
 func (pgb *ProductGroupBy) Scan(ctx context.Context, v interface{}) error {
+	query, err := pgb.path(ctx)
+	if err != nil {
+		return err
+	}
+	pgb.sql = query
 	return pgb.sqlScan(ctx, v)
 }
 
@@ -500,10 +625,17 @@ type ProductSelect struct {
 	fields []string
 	// intermediate queries.
 	sql *sql.Selector
+	// path returns the intermediate query, once the query is prepared.
+	path func(context.Context) (*sql.Selector, error)
 }
 
 // Scan applies the selector query and scan the result into the given value.
 func (ps *ProductSelect) Scan(ctx context.Context, v interface{}) error {
+	query, err := ps.path(ctx)
+	if err != nil {
+		return err
+	}
+	ps.sql = query
 	return ps.sqlScan(ctx, v)
 }
 
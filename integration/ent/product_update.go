@@ -10,6 +10,7 @@ import (
 	"github.com/facebookincubator/ent/dialect/sql"
 	"github.com/facebookincubator/ent/dialect/sql/sqlgraph"
 	"github.com/facebookincubator/ent/schema/field"
+	"github.com/phogolabs/ent/integration/ent/category"
 	"github.com/phogolabs/ent/integration/ent/predicate"
 	"github.com/phogolabs/ent/integration/ent/product"
 )
@@ -28,6 +29,26 @@ func (pu *ProductUpdate) Where(ps ...predicate.Product) *ProductUpdate {
 	return pu
 }
 
+// SetDeletedAt sets the deleted_at field.
+func (pu *ProductUpdate) SetDeletedAt(t time.Time) *ProductUpdate {
+	pu.mutation.SetDeletedAt(t)
+	return pu
+}
+
+// SetNillableDeletedAt sets the deleted_at field if the given value is not nil.
+func (pu *ProductUpdate) SetNillableDeletedAt(t *time.Time) *ProductUpdate {
+	if t != nil {
+		pu.SetDeletedAt(*t)
+	}
+	return pu
+}
+
+// ClearDeletedAt clears the value of deleted_at.
+func (pu *ProductUpdate) ClearDeletedAt() *ProductUpdate {
+	pu.mutation.ClearDeletedAt()
+	return pu
+}
+
 // SetTitle sets the title field.
 func (pu *ProductUpdate) SetTitle(s string) *ProductUpdate {
 	pu.mutation.SetTitle(s)
@@ -40,6 +61,51 @@ func (pu *ProductUpdate) SetUpdatedAt(t time.Time) *ProductUpdate {
 	return pu
 }
 
+// SetDescription sets the description field.
+func (pu *ProductUpdate) SetDescription(s string) *ProductUpdate {
+	pu.mutation.SetDescription(s)
+	return pu
+}
+
+// SetNillableDescription sets the description field if the given value is not nil.
+func (pu *ProductUpdate) SetNillableDescription(s *string) *ProductUpdate {
+	if s != nil {
+		pu.SetDescription(*s)
+	}
+	return pu
+}
+
+// ClearDescription clears the value of description.
+func (pu *ProductUpdate) ClearDescription() *ProductUpdate {
+	pu.mutation.ClearDescription()
+	return pu
+}
+
+// SetCategoryID sets the category edge to Category by id.
+func (pu *ProductUpdate) SetCategoryID(id int) *ProductUpdate {
+	pu.mutation.SetCategoryID(id)
+	return pu
+}
+
+// SetNillableCategoryID sets the category edge to Category by id if the given value is not nil.
+func (pu *ProductUpdate) SetNillableCategoryID(id *int) *ProductUpdate {
+	if id != nil {
+		pu = pu.SetCategoryID(*id)
+	}
+	return pu
+}
+
+// SetCategory sets the category edge to Category.
+func (pu *ProductUpdate) SetCategory(c *Category) *ProductUpdate {
+	return pu.SetCategoryID(c.ID)
+}
+
+// ClearCategory clears the category edge to Category.
+func (pu *ProductUpdate) ClearCategory() *ProductUpdate {
+	pu.mutation.ClearCategory()
+	return pu
+}
+
 // Save executes the query and returns the number of rows/vertices matched by this operation.
 func (pu *ProductUpdate) Save(ctx context.Context) (int, error) {
 	if v, ok := pu.mutation.Title(); ok {
@@ -51,6 +117,7 @@ func (pu *ProductUpdate) Save(ctx context.Context) (int, error) {
 		v := product.UpdateDefaultUpdatedAt()
 		pu.mutation.SetUpdatedAt(v)
 	}
+
 	var (
 		err      error
 		affected int
@@ -117,6 +184,19 @@ func (pu *ProductUpdate) sqlSave(ctx context.Context) (n int, err error) {
 			}
 		}
 	}
+	if value, ok := pu.mutation.DeletedAt(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeTime,
+			Value:  value,
+			Column: product.FieldDeletedAt,
+		})
+	}
+	if pu.mutation.DeletedAtCleared() {
+		_spec.Fields.Clear = append(_spec.Fields.Clear, &sqlgraph.FieldSpec{
+			Type:   field.TypeTime,
+			Column: product.FieldDeletedAt,
+		})
+	}
 	if value, ok := pu.mutation.Title(); ok {
 		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
 			Type:   field.TypeString,
@@ -131,6 +211,54 @@ func (pu *ProductUpdate) sqlSave(ctx context.Context) (n int, err error) {
 			Column: product.FieldUpdatedAt,
 		})
 	}
+	if value, ok := pu.mutation.Description(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: product.FieldDescription,
+		})
+	}
+	if pu.mutation.DescriptionCleared() {
+		_spec.Fields.Clear = append(_spec.Fields.Clear, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Column: product.FieldDescription,
+		})
+	}
+	if pu.mutation.CategoryCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   product.CategoryTable,
+			Columns: []string{product.CategoryColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: &sqlgraph.FieldSpec{
+					Type:   field.TypeInt,
+					Column: category.FieldID,
+				},
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := pu.mutation.CategoryIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   product.CategoryTable,
+			Columns: []string{product.CategoryColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: &sqlgraph.FieldSpec{
+					Type:   field.TypeInt,
+					Column: category.FieldID,
+				},
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
 	if n, err = sqlgraph.UpdateNodes(ctx, pu.driver, _spec); err != nil {
 		if _, ok := err.(*sqlgraph.NotFoundError); ok {
 			err = &NotFoundError{product.Label}
@@ -149,6 +277,26 @@ type ProductUpdateOne struct {
 	mutation *ProductMutation
 }
 
+// SetDeletedAt sets the deleted_at field.
+func (puo *ProductUpdateOne) SetDeletedAt(t time.Time) *ProductUpdateOne {
+	puo.mutation.SetDeletedAt(t)
+	return puo
+}
+
+// SetNillableDeletedAt sets the deleted_at field if the given value is not nil.
+func (puo *ProductUpdateOne) SetNillableDeletedAt(t *time.Time) *ProductUpdateOne {
+	if t != nil {
+		puo.SetDeletedAt(*t)
+	}
+	return puo
+}
+
+// ClearDeletedAt clears the value of deleted_at.
+func (puo *ProductUpdateOne) ClearDeletedAt() *ProductUpdateOne {
+	puo.mutation.ClearDeletedAt()
+	return puo
+}
+
 // SetTitle sets the title field.
 func (puo *ProductUpdateOne) SetTitle(s string) *ProductUpdateOne {
 	puo.mutation.SetTitle(s)
@@ -161,6 +309,51 @@ func (puo *ProductUpdateOne) SetUpdatedAt(t time.Time) *ProductUpdateOne {
 	return puo
 }
 
+// SetDescription sets the description field.
+func (puo *ProductUpdateOne) SetDescription(s string) *ProductUpdateOne {
+	puo.mutation.SetDescription(s)
+	return puo
+}
+
+// SetNillableDescription sets the description field if the given value is not nil.
+func (puo *ProductUpdateOne) SetNillableDescription(s *string) *ProductUpdateOne {
+	if s != nil {
+		puo.SetDescription(*s)
+	}
+	return puo
+}
+
+// ClearDescription clears the value of description.
+func (puo *ProductUpdateOne) ClearDescription() *ProductUpdateOne {
+	puo.mutation.ClearDescription()
+	return puo
+}
+
+// SetCategoryID sets the category edge to Category by id.
+func (puo *ProductUpdateOne) SetCategoryID(id int) *ProductUpdateOne {
+	puo.mutation.SetCategoryID(id)
+	return puo
+}
+
+// SetNillableCategoryID sets the category edge to Category by id if the given value is not nil.
+func (puo *ProductUpdateOne) SetNillableCategoryID(id *int) *ProductUpdateOne {
+	if id != nil {
+		puo = puo.SetCategoryID(*id)
+	}
+	return puo
+}
+
+// SetCategory sets the category edge to Category.
+func (puo *ProductUpdateOne) SetCategory(c *Category) *ProductUpdateOne {
+	return puo.SetCategoryID(c.ID)
+}
+
+// ClearCategory clears the category edge to Category.
+func (puo *ProductUpdateOne) ClearCategory() *ProductUpdateOne {
+	puo.mutation.ClearCategory()
+	return puo
+}
+
 // Save executes the query and returns the updated entity.
 func (puo *ProductUpdateOne) Save(ctx context.Context) (*Product, error) {
 	if v, ok := puo.mutation.Title(); ok {
@@ -172,6 +365,7 @@ func (puo *ProductUpdateOne) Save(ctx context.Context) (*Product, error) {
 		v := product.UpdateDefaultUpdatedAt()
 		puo.mutation.SetUpdatedAt(v)
 	}
+
 	var (
 		err  error
 		node *Product
@@ -236,6 +430,19 @@ func (puo *ProductUpdateOne) sqlSave(ctx context.Context) (pr *Product, err erro
 		return nil, fmt.Errorf("missing Product.ID for update")
 	}
 	_spec.Node.ID.Value = id
+	if value, ok := puo.mutation.DeletedAt(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeTime,
+			Value:  value,
+			Column: product.FieldDeletedAt,
+		})
+	}
+	if puo.mutation.DeletedAtCleared() {
+		_spec.Fields.Clear = append(_spec.Fields.Clear, &sqlgraph.FieldSpec{
+			Type:   field.TypeTime,
+			Column: product.FieldDeletedAt,
+		})
+	}
 	if value, ok := puo.mutation.Title(); ok {
 		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
 			Type:   field.TypeString,
@@ -250,6 +457,54 @@ func (puo *ProductUpdateOne) sqlSave(ctx context.Context) (pr *Product, err erro
 			Column: product.FieldUpdatedAt,
 		})
 	}
+	if value, ok := puo.mutation.Description(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: product.FieldDescription,
+		})
+	}
+	if puo.mutation.DescriptionCleared() {
+		_spec.Fields.Clear = append(_spec.Fields.Clear, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Column: product.FieldDescription,
+		})
+	}
+	if puo.mutation.CategoryCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   product.CategoryTable,
+			Columns: []string{product.CategoryColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: &sqlgraph.FieldSpec{
+					Type:   field.TypeInt,
+					Column: category.FieldID,
+				},
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := puo.mutation.CategoryIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   product.CategoryTable,
+			Columns: []string{product.CategoryColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: &sqlgraph.FieldSpec{
+					Type:   field.TypeInt,
+					Column: category.FieldID,
+				},
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
 	pr = &Product{config: puo.config}
 	_spec.Assign = pr.assignValues
 	_spec.ScanValues = pr.scanValues()
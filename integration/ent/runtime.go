@@ -5,6 +5,7 @@ package ent
 import (
 	"time"
 
+	"github.com/phogolabs/ent/integration/ent/category"
 	"github.com/phogolabs/ent/integration/ent/product"
 	"github.com/phogolabs/ent/integration/ent/schema"
 )
@@ -13,6 +14,12 @@ import (
 // code (default values, validators or hooks) and stitches it
 // to their package variables.
 func init() {
+	categoryFields := schema.Category{}.Fields()
+	_ = categoryFields
+	// categoryDescName is the schema descriptor for name field.
+	categoryDescName := categoryFields[0].Descriptor()
+	// category.NameValidator is a validator for the "name" field. It is called by the builders before save.
+	category.NameValidator = categoryDescName.Validators[0].(func(string) error)
 	productFields := schema.Product{}.Fields()
 	_ = productFields
 	// productDescTitle is the schema descriptor for title field.
@@ -4,8 +4,11 @@ import (
 	"time"
 
 	"github.com/facebookincubator/ent"
+	"github.com/facebookincubator/ent/schema/edge"
 	"github.com/facebookincubator/ent/schema/field"
 	"github.com/google/uuid"
+	"github.com/phogolabs/ent/pagination"
+	"github.com/phogolabs/ent/softdelete"
 )
 
 // Product holds the schema definition for the Product entity.
@@ -13,6 +16,13 @@ type Product struct {
 	ent.Schema
 }
 
+// Mixin of the Product.
+func (Product) Mixin() []ent.Mixin {
+	return []ent.Mixin{
+		softdelete.Mixin{},
+	}
+}
+
 // Fields of the Product.
 func (Product) Fields() []ent.Field {
 	return []ent.Field{
@@ -22,18 +32,39 @@ func (Product) Fields() []ent.Field {
 			Default(uuid.New),
 		field.
 			String("title").
-			NotEmpty(),
+			NotEmpty().
+			StructTag(pagination.Tag{
+				Sortable:   true,
+				Searchable: true,
+				Orderings:  []string{"+title,+id"},
+			}.String()),
 		field.Time("created_at").
 			Default(time.Now).
-			Immutable(),
+			Immutable().
+			StructTag(pagination.Tag{
+				Sortable:  true,
+				Orderings: []string{"-created_at,-id"},
+			}.String()),
 		field.
 			Time("updated_at").
 			Default(time.Now).
-			UpdateDefault(time.Now),
+			UpdateDefault(time.Now).
+			StructTag(pagination.Tag{Sortable: true}.String()),
+		field.
+			String("description").
+			Optional().
+			Nillable().
+			StructTag(pagination.Tag{Sortable: true}.String()),
 	}
 }
 
 // Edges of the Product.
 func (Product) Edges() []ent.Edge {
-	return nil
+	return []ent.Edge{
+		edge.
+			From("category", Category.Type).
+			Ref("products").
+			Unique().
+			StructTag(pagination.Tag{Sortable: true}.String()),
+	}
 }
@@ -2,31 +2,101 @@ package integration_test
 
 import (
 	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
 
 	"github.com/davecgh/go-spew/spew"
+	"github.com/go-sql-driver/mysql"
+	"github.com/facebookincubator/ent/dialect"
+	entsql "github.com/facebookincubator/ent/dialect/sql"
+	"github.com/google/uuid"
 	"github.com/phogolabs/ent/integration/ent"
+	"github.com/phogolabs/ent/integration/ent/category"
+	"github.com/phogolabs/ent/integration/ent/migrate"
+	"github.com/phogolabs/ent/integration/ent/product"
 
 	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
 	. "github.com/onsi/gomega"
 )
 
-var _ = Describe("Pagination", func() {
+var _ = Describe("ParseOrder", func() {
+	DescribeTable("parses the order",
+		func(order, expected string) {
+			terms, err := ent.ParseOrder(order)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ent.FormatOrder(terms)).To(Equal(expected))
+
+			// the formatted order is parsed back to the same terms
+			parsed, err := ent.ParseOrder(expected)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(parsed).To(Equal(terms))
+		},
+		Entry("signed", "+title,-id", "+title,-id"),
+		Entry("unsigned", "title, id", "+title,+id"),
+		Entry("modifiers", "-title:ci:collate=und-x-icu:nullsfirst", "-title:ci:collate=und-x-icu:nullsfirst"),
+		Entry("JSON:API", "sort=-created_at,title", "-created_at,+title"),
+		Entry("SQL", "order_by=title DESC NULLS LAST, id asc", "-title:nullslast,+id"),
+		Entry("empty", "", ""),
+	)
+
+	DescribeTable("returns the position of a syntax error",
+		func(order string, offset int, reason string) {
+			terms, err := ent.ParseOrder(order)
+			Expect(ent.IsOrderSyntaxError(err)).To(BeTrue())
+			Expect(terms).To(BeNil())
+
+			e := err.(*ent.OrderSyntaxError)
+			Expect(e.Offset).To(Equal(offset))
+			Expect(e.Reason).To(Equal(reason))
+		},
+		Entry("missing column", "+title,-", 8, "invalid '' column"),
+		Entry("invalid column", "+title;", 1, "invalid 'title;' column"),
+		Entry("unknown modifier", "+title:nullsmiddle", 7, "unknown 'nullsmiddle' modifier"),
+		Entry("duplicate direction", "-title desc", 7, "unexpected 'desc'"),
+		Entry("unknown keyword", "order_by=title desc nulls middle", 26, "unexpected 'middle'"),
+	)
+})
+
+// nextCursor returns the cursor after the records, which is expected to be built.
+func nextCursor(cursor *ent.ProductCursor, records []*ent.Product) *ent.ProductCursor {
+	next, err := cursor.Next(records)
+	Expect(err).NotTo(HaveOccurred())
+	return next
+}
+
+// prevCursor returns the cursor before the records, which is expected to be built.
+func prevCursor(cursor *ent.ProductCursor, records []*ent.Product) *ent.ProductCursor {
+	prev, err := cursor.Prev(records)
+	Expect(err).NotTo(HaveOccurred())
+	return prev
+}
+
+// versionDriver reports the given version of the SQLite server.
+type versionDriver struct {
+	dialect.Driver
+	version string
+}
+
+// Query executes the query of the version as a query of the given one.
+func (d *versionDriver) Query(ctx context.Context, query string, args, v interface{}) error {
+	if query == "SELECT sqlite_version()" {
+		query = fmt.Sprintf("SELECT '%s'", d.version)
+	}
+
+	return d.Driver.Query(ctx, query, args, v)
+}
+
+var _ = describeDialects("Pagination", func(db *database) {
 	var (
 		ctx    = context.TODO()
 		client *ent.Client
 	)
 
 	BeforeEach(func() {
-		var err error
-
-		// client, err = ent.Open("sqlite3", "file:ent?mode=memory&cache=shared&_fk=1", ent.Debug())
-		client, err = ent.Open("postgres", "postgres://localhost:5432/ent?sslmode=disable", ent.Debug())
-		Expect(err).NotTo(HaveOccurred())
-		Expect(client.Schema.Create(ctx)).To(Succeed())
-	})
-
-	AfterEach(func() {
-		Expect(client.Close()).To(Succeed())
+		client = db.open(ent.Debug())
 	})
 
 	Describe("Query", func() {
@@ -68,11 +138,14 @@ var _ = Describe("Pagination", func() {
 			return records
 		}
 
-		AfterEach(func() {
-			for _, entity := range entities {
-				Expect(client.Product.DeleteOneID(entity.ID).Exec(ctx)).To(Succeed())
-			}
-		})
+		queryBefore := func(cursor *ent.ProductCursor, limit int) []*ent.Product {
+			query := client.Product.Query().SeekBefore(cursor).Limit(limit)
+
+			records, err := query.All(ctx)
+			Expect(err).NotTo(HaveOccurred())
+
+			return records
+		}
 
 		It("returns the entities page by page", func() {
 			cursor, err := ent.DecodeProductCursor("+title,+id", "")
@@ -87,7 +160,7 @@ var _ = Describe("Pagination", func() {
 			Expect(records[1].Title).To(Equal("Hat"))
 
 			// fetch next page
-			cursor = cursor.Next(records)
+			cursor = nextCursor(cursor, records)
 
 			records = query(cursor, 2)
 			Expect(records).To(HaveLen(2))
@@ -95,7 +168,7 @@ var _ = Describe("Pagination", func() {
 			Expect(records[1].Title).To(Equal("Hat"))
 
 			// fetch next page
-			cursor = cursor.Next(records)
+			cursor = nextCursor(cursor, records)
 
 			records = query(cursor, 2)
 			Expect(records).To(HaveLen(2))
@@ -103,7 +176,7 @@ var _ = Describe("Pagination", func() {
 			Expect(records[1].Title).To(Equal("Pants"))
 
 			// fetch next page
-			cursor = cursor.Next(records)
+			cursor = nextCursor(cursor, records)
 
 			records = query(cursor, 2)
 			Expect(records).To(HaveLen(2))
@@ -111,19 +184,1225 @@ var _ = Describe("Pagination", func() {
 			Expect(records[1].Title).To(Equal("T-Shirt"))
 
 			// fetch next page
-			cursor = cursor.Next(records)
+			cursor = nextCursor(cursor, records)
 
 			records = query(cursor, 2)
 			Expect(records).To(HaveLen(2))
 			Expect(records[0].Title).To(Equal("T-Shirt"))
 			Expect(records[1].Title).To(Equal("Trousers"))
 
-			cursor = cursor.Next(records)
+			cursor = nextCursor(cursor, records)
 
 			records = query(cursor, 2)
 			Expect(records).To(HaveLen(0))
 
-			cursor = cursor.Next(records)
+			cursor = nextCursor(cursor, records)
+		})
+
+		It("returns the entities page by page using the SQL form of the order", func() {
+			cursor, err := ent.DecodeProductCursor("order_by=title desc, id", "")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cursor.String()).NotTo(BeEmpty())
+
+			records := query(cursor, 2)
+			Expect(records).To(HaveLen(2))
+			Expect(records[0].Title).To(Equal("Trousers"))
+			Expect(records[1].Title).To(Equal("T-Shirt"))
+		})
+
+		It("returns the entities page by page using cursor tokens", func() {
+			const order = "-created_at,+id"
+
+			expected, err := client.Product.Query().
+				Order(ent.Desc("created_at"), ent.Asc("id")).
+				All(ctx)
+			Expect(err).NotTo(HaveOccurred())
+
+			cursor, err := ent.DecodeProductCursor(order, "")
+			Expect(err).NotTo(HaveOccurred())
+
+			actual := []*ent.Product{}
+
+			for {
+				records := query(cursor, 3)
+
+				if len(records) == 0 {
+					break
+				}
+
+				actual = append(actual, records...)
+
+				// round-trip the cursor through its string representation
+				cursor, err = ent.DecodeProductCursor(order, nextCursor(cursor, records).String())
+				Expect(err).NotTo(HaveOccurred())
+			}
+
+			Expect(actual).To(HaveLen(len(expected)))
+
+			for index, entity := range expected {
+				Expect(actual[index].ID).To(Equal(entity.ID))
+			}
+		})
+
+		It("decodes a cursor by using the order of its token", func() {
+			cursor, err := ent.DecodeProductCursor("+title,+id", "")
+			Expect(err).NotTo(HaveOccurred())
+
+			records := query(cursor, 2)
+			Expect(records).To(HaveLen(2))
+
+			token := nextCursor(cursor, records).String()
+
+			cursor, err = ent.DecodeProductCursorToken(token)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cursor.String()).To(Equal(token))
+
+			records = query(cursor, 2)
+			Expect(records).To(HaveLen(2))
+			Expect(records[0].Title).To(Equal("Hat"))
+			Expect(records[1].Title).To(Equal("Hat"))
+		})
+
+		It("returns an error when the order does not match the cursor", func() {
+			cursor, err := ent.DecodeProductCursor("+title,+id", "")
+			Expect(err).NotTo(HaveOccurred())
+
+			records := query(cursor, 2)
+			Expect(records).To(HaveLen(2))
+
+			token := nextCursor(cursor, records).String()
+
+			cursor, err = ent.DecodeProductCursor("title,id", token)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cursor).NotTo(BeNil())
+
+			cursor, err = ent.DecodeProductCursor("-created_at", token)
+			Expect(ent.IsCursorMismatch(err)).To(BeTrue())
+			Expect(cursor).To(BeNil())
+		})
+
+		It("returns an error when the column is not sortable", func() {
+			cursor, err := ent.DecodeProductCursor("-deleted_at,+id", "")
+			Expect(ent.IsUnsortableColumn(err)).To(BeTrue())
+			Expect(err).To(MatchError("ent: column 'deleted_at' is not sortable"))
+			Expect(cursor).To(BeNil())
+		})
+
+		Context("when the cursors are signed", func() {
+			var (
+				current  = &ent.CursorKey{ID: "v2", Secret: []byte("current"), Cipher: []byte("0123456789abcdef")}
+				previous = &ent.CursorKey{ID: "v1", Secret: []byte("previous")}
+			)
+
+			next := func(keys ...*ent.CursorKey) string {
+				codec, err := ent.NewCursorCodec(keys...)
+				Expect(err).NotTo(HaveOccurred())
+
+				ent.SetCursorCodec(codec)
+
+				cursor, err := ent.DecodeProductCursor("+title,+id", "")
+				Expect(err).NotTo(HaveOccurred())
+
+				return nextCursor(cursor, query(cursor, 2)).String()
+			}
+
+			AfterEach(func() {
+				ent.SetCursorCodec(nil)
+			})
+
+			It("decodes the cursors signed with any of the keys", func() {
+				token := next(previous)
+				Expect(token).To(HavePrefix("v1."))
+
+				token = next(current, previous)
+				Expect(token).To(HavePrefix("v2."))
+
+				for _, token := range []string{next(previous), next(current)} {
+					codec, err := ent.NewCursorCodec(current, previous)
+					Expect(err).NotTo(HaveOccurred())
+
+					ent.SetCursorCodec(codec)
+
+					cursor, err := ent.DecodeProductCursor("+title,+id", token)
+					Expect(err).NotTo(HaveOccurred())
+
+					records := query(cursor, 2)
+					Expect(records).To(HaveLen(2))
+					Expect(records[0].Title).To(Equal("Hat"))
+					Expect(records[1].Title).To(Equal("Hat"))
+				}
+			})
+
+			It("returns an error when the token is tampered", func() {
+				token := next(current)
+				token = token[:len(token)-2] + "AA"
+
+				cursor, err := ent.DecodeProductCursor("+title,+id", token)
+				Expect(ent.IsCursorSignatureError(err)).To(BeTrue())
+				Expect(cursor).To(BeNil())
+			})
+
+			It("returns an error when the key is unknown", func() {
+				token := next(previous)
+
+				codec, err := ent.NewCursorCodec(current)
+				Expect(err).NotTo(HaveOccurred())
+
+				ent.SetCursorCodec(codec)
+
+				cursor, err := ent.DecodeProductCursor("+title,+id", token)
+				Expect(ent.IsCursorSignatureError(err)).To(BeTrue())
+				Expect(cursor).To(BeNil())
+			})
+
+			It("decodes the cursors while the codec is replaced", func() {
+				token := next(current)
+
+				codec, err := ent.NewCursorCodec(current)
+				Expect(err).NotTo(HaveOccurred())
+
+				var wg sync.WaitGroup
+
+				for index := 0; index < 4; index++ {
+					wg.Add(1)
+
+					go func() {
+						defer GinkgoRecover()
+						defer wg.Done()
+
+						for count := 0; count < 100; count++ {
+							cursor, err := ent.DecodeProductCursor("+title,+id", token)
+							Expect(err).NotTo(HaveOccurred())
+							Expect(cursor.String()).To(HavePrefix("v2."))
+						}
+					}()
+				}
+
+				for count := 0; count < 100; count++ {
+					ent.SetCursorCodec(codec)
+				}
+
+				wg.Wait()
+			})
+
+			It("returns an error when the token is not signed", func() {
+				cursor, err := ent.DecodeProductCursor("+title,+id", "")
+				Expect(err).NotTo(HaveOccurred())
+
+				token := nextCursor(cursor, query(cursor, 2)).String()
+
+				codec, err := ent.NewCursorCodec(current)
+				Expect(err).NotTo(HaveOccurred())
+
+				ent.SetCursorCodec(codec)
+
+				cursor, err = ent.DecodeProductCursor("+title,+id", token)
+				Expect(ent.IsCursorSignatureError(err)).To(BeTrue())
+				Expect(cursor).To(BeNil())
+			})
+		})
+
+		It("resumes the pagination from any item of a page", func() {
+			cursor, err := ent.DecodeProductCursor("+title,+id", "")
+			Expect(err).NotTo(HaveOccurred())
+
+			records := query(cursor, 5)
+			Expect(records).To(HaveLen(5))
+
+			cursor, err = cursor.CursorFor(records[2])
+			Expect(err).NotTo(HaveOccurred())
+
+			resumed := query(cursor, 2)
+			Expect(resumed).To(HaveLen(2))
+			Expect(resumed[0].ID).To(Equal(records[3].ID))
+			Expect(resumed[1].ID).To(Equal(records[4].ID))
+		})
+
+		Context("when the orderings are annotated", func() {
+			var logs []string
+
+			BeforeEach(func() {
+				logs = []string{}
+
+				// replace the client with one that records the logs
+				client = db.open(ent.Debug(), ent.Log(func(args ...interface{}) {
+					logs = append(logs, fmt.Sprint(args...))
+				}))
+			})
+
+			warnings := func() []string {
+				items := []string{}
+
+				for _, item := range logs {
+					if strings.Contains(item, "no supporting index") {
+						items = append(items, item)
+					}
+				}
+
+				return items
+			}
+
+			It("adds an index for each of the orderings to the migration", func() {
+				names := []string{}
+
+				for _, index := range migrate.ProductsTable.Indexes {
+					names = append(names, index.Name)
+				}
+
+				Expect(names).To(ContainElement("product_title_id"))
+				Expect(names).To(ContainElement("product_created_at_id"))
+			})
+
+			It("does not log a warning when the order has a supporting index", func() {
+				cursor, err := ent.DecodeProductCursor("+title", "")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(query(cursor, 2)).To(HaveLen(2))
+				Expect(warnings()).To(BeEmpty())
+			})
+
+			It("logs a warning when the order has no supporting index", func() {
+				cursor, err := ent.DecodeProductCursor("+description", "")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(query(cursor, 2)).To(HaveLen(2))
+				Expect(warnings()).To(ConsistOf("ent: order '+description:nullslast,+id' of Product has no supporting index"))
+			})
+
+			It("does not log a warning when the order is the opposite of the ordering", func() {
+				// the index of '-created_at,-id' is scanned backwards
+				cursor, err := ent.DecodeProductCursor("+created_at", "")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(query(cursor, 2)).To(HaveLen(2))
+				Expect(warnings()).To(BeEmpty())
+			})
+
+			It("logs a warning when the order has mixed directions", func() {
+				cursor, err := ent.DecodeProductCursor("-created_at,+id", "")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(query(cursor, 2)).To(HaveLen(2))
+				Expect(warnings()).To(ConsistOf("ent: order '-created_at,+id' of Product has no supporting index"))
+			})
+
+			It("breaks the ties by the identifier in the direction of the last column", func() {
+				db.only(dialect.SQLite)
+
+				drv, err := entsql.Open(db.dialect(), db.dsn)
+				Expect(err).NotTo(HaveOccurred())
+				defer drv.Close()
+
+				// the client records the logs and the last query, so its plan can be explained
+				explain := &explainDriver{Driver: drv}
+				client := ent.NewClient(ent.Driver(explain), ent.Debug(), ent.Log(func(args ...interface{}) {
+					logs = append(logs, fmt.Sprint(args...))
+				}))
+
+				cursor, err := ent.DecodeProductCursor("-created_at", "")
+				Expect(err).NotTo(HaveOccurred())
+
+				records, err := client.Product.Query().Seek(cursor).Limit(2).All(ctx)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(records).To(HaveLen(2))
+
+				cursor, err = cursor.Next(records)
+				Expect(err).NotTo(HaveOccurred())
+
+				// the token has the order of the ordering '-created_at,-id'
+				_, err = ent.DecodeProductCursor("-created_at,-id", cursor.String())
+				Expect(err).NotTo(HaveOccurred())
+
+				logs = []string{}
+
+				records, err = client.Product.Query().Seek(cursor).Limit(2).All(ctx)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(records).To(HaveLen(2))
+				Expect(warnings()).To(BeEmpty())
+
+				// the positions are compared as a row value, which is seeked by the index
+				Expect(logs).To(ContainElement(ContainSubstring("(`products`.`created_at`, `products`.`id`) < (?, ?)")))
+
+				plan, err := explain.explain(ctx, drv.DB())
+				Expect(err).NotTo(HaveOccurred())
+				Expect(plan).To(ContainSubstring("INDEX product_created_at_id"))
+			})
+		})
+
+		Context("when the order does not have a unique column", func() {
+			It("returns the entities page by page", func() {
+				expected, err := client.Product.Query().
+					Order(ent.Asc("title"), ent.Asc("id")).
+					All(ctx)
+				Expect(err).NotTo(HaveOccurred())
+
+				cursor, err := ent.DecodeProductCursor("+title", "")
+				Expect(err).NotTo(HaveOccurred())
+
+				actual := []*ent.Product{}
+
+				for {
+					records := query(cursor, 2)
+
+					if len(records) == 0 {
+						break
+					}
+
+					actual = append(actual, records...)
+
+					cursor, err = ent.DecodeProductCursor("+title", nextCursor(cursor, records).String())
+					Expect(err).NotTo(HaveOccurred())
+				}
+
+				Expect(actual).To(HaveLen(len(expected)))
+
+				for index, entity := range expected {
+					Expect(actual[index].ID).To(Equal(entity.ID))
+				}
+			})
+
+			It("appends the identifier to the order of the token", func() {
+				cursor, err := ent.DecodeProductCursor("+title", "")
+				Expect(err).NotTo(HaveOccurred())
+
+				token := nextCursor(cursor, query(cursor, 2)).String()
+
+				cursor, err = ent.DecodeProductCursor("+title,+id", token)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(cursor).NotTo(BeNil())
+			})
+
+			It("does not append the identifier when the tiebreaker is disabled", func() {
+				cursor, err := ent.DecodeProductCursor("+title", "", ent.WithoutTiebreaker())
+				Expect(err).NotTo(HaveOccurred())
+
+				token := nextCursor(cursor, query(cursor, 2)).String()
+
+				cursor, err = ent.DecodeProductCursor("+title,+id", token)
+				Expect(ent.IsCursorMismatch(err)).To(BeTrue())
+				Expect(cursor).To(BeNil())
+
+				cursor, err = ent.DecodeProductCursorToken(token)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(cursor.String()).To(Equal(token))
+			})
+		})
+
+		Context("when the order has a text modifier", func() {
+			BeforeEach(func() {
+				for _, title := range []string{"cap", "hat"} {
+					entity, err := client.Product.Create().
+						SetID(imap[len(entities)]).
+						SetTitle(title).
+						Save(ctx)
+
+					Expect(err).NotTo(HaveOccurred())
+					entities = append(entities, entity)
+				}
+			})
+
+			walk := func(order string) []string {
+				cursor, err := ent.DecodeProductCursor(order, "")
+				Expect(err).NotTo(HaveOccurred())
+
+				titles := []string{}
+
+				for {
+					records := query(cursor, 5)
+
+					if len(records) == 0 {
+						return titles
+					}
+
+					for _, record := range records {
+						titles = append(titles, record.Title)
+					}
+
+					cursor, err = ent.DecodeProductCursor(order, nextCursor(cursor, records).String())
+					Expect(err).NotTo(HaveOccurred())
+				}
+			}
+
+			It("returns the entities page by page regardless of their case", func() {
+				titles := walk("+title:ci")
+				Expect(titles).To(HaveLen(len(entities)))
+
+				Expect(sort.SliceIsSorted(titles, func(i, j int) bool {
+					return strings.ToLower(titles[i]) < strings.ToLower(titles[j])
+				})).To(BeTrue())
+			})
+
+			It("compares the folded values that have quotes and backslashes", func() {
+				for _, title := range []string{`Bob's \'Hat`, `bob's \'cap`} {
+					entity, err := client.Product.Create().
+						SetID(imap[len(entities)]).
+						SetTitle(title).
+						Save(ctx)
+
+					Expect(err).NotTo(HaveOccurred())
+					entities = append(entities, entity)
+				}
+
+				titles := walk("+title:ci")
+				Expect(titles).To(HaveLen(len(entities)))
+				Expect(titles).To(ContainElement(`Bob's \'Hat`))
+				Expect(titles).To(ContainElement(`bob's \'cap`))
+			})
+
+			It("compares the folded values when the backslashes are not escapes", func() {
+				db.only(dialect.MySQL)
+
+				for _, title := range []string{`Bob's \'Hat`, `bob's \'cap`} {
+					entity, err := client.Product.Create().
+						SetID(imap[len(entities)]).
+						SetTitle(title).
+						Save(ctx)
+
+					Expect(err).NotTo(HaveOccurred())
+					entities = append(entities, entity)
+				}
+
+				config, err := mysql.ParseDSN(db.dsn)
+				Expect(err).NotTo(HaveOccurred())
+
+				// the mode is set on each connection of the client
+				config.Params = map[string]string{
+					"sql_mode": "CONCAT(@@sql_mode, ',NO_BACKSLASH_ESCAPES')",
+				}
+
+				client, err = ent.Open(dialect.MySQL, config.FormatDSN())
+				Expect(err).NotTo(HaveOccurred())
+				db.clients = append(db.clients, client)
+
+				titles := walk("+title:ci")
+				Expect(titles).To(HaveLen(len(entities)))
+				Expect(titles).To(ContainElement(`Bob's \'Hat`))
+				Expect(titles).To(ContainElement(`bob's \'cap`))
+			})
+
+			It("returns the entities page by page by the collation", func() {
+				// the C collation is specific to Postgres
+				db.only(dialect.Postgres)
+
+				titles := walk("+title:collate=C")
+				Expect(titles).To(HaveLen(len(entities)))
+				Expect(titles[len(titles)-2:]).To(Equal([]string{"cap", "hat"}))
+			})
+
+			It("keeps the modifier in the order of the token", func() {
+				cursor, err := ent.DecodeProductCursor("+title:ci", "")
+				Expect(err).NotTo(HaveOccurred())
+
+				token := nextCursor(cursor, query(cursor, 2)).String()
+
+				cursor, err = ent.DecodeProductCursor("+title,+id", token)
+				Expect(ent.IsCursorMismatch(err)).To(BeTrue())
+				Expect(cursor).To(BeNil())
+			})
+
+			It("returns an error when the column is not a text", func() {
+				cursor, err := ent.DecodeProductCursor("+created_at:ci", "")
+				Expect(err).To(MatchError("ent: 'created_at' column does not support text modifiers"))
+				Expect(cursor).To(BeNil())
+			})
+
+			It("returns an error when the collation is invalid", func() {
+				cursor, err := ent.DecodeProductCursor(`+title:collate=C"`, "")
+				Expect(err).To(MatchError(`ent: invalid 'C"' collation at offset 15`))
+				Expect(cursor).To(BeNil())
+			})
+		})
+
+		Context("when the order has an optional field", func() {
+			BeforeEach(func() {
+				descriptions := []string{"Blue", "Red", "Blue", "Green"}
+
+				for index, description := range descriptions {
+					entity, err := entities[index*2].Update().
+						SetDescription(description).
+						Save(ctx)
+
+					Expect(err).NotTo(HaveOccurred())
+					entities[index*2] = entity
+				}
+			})
+
+			walk := func(order string) []*ent.Product {
+				cursor, err := ent.DecodeProductCursor(order, "")
+				Expect(err).NotTo(HaveOccurred())
+
+				items := []*ent.Product{}
+
+				for {
+					records := query(cursor, 3)
+
+					if len(records) == 0 {
+						return items
+					}
+
+					items = append(items, records...)
+
+					cursor, err = ent.DecodeProductCursor(order, nextCursor(cursor, records).String())
+					Expect(err).NotTo(HaveOccurred())
+				}
+			}
+
+			nulls := func(items []*ent.Product) []bool {
+				values := []bool{}
+
+				for _, item := range items {
+					values = append(values, item.Description == nil)
+				}
+
+				return values
+			}
+
+			DescribeTable("returns the entities page by page",
+				func(order string, first bool) {
+					items := walk(order)
+					Expect(items).To(HaveLen(len(entities)))
+
+					cursor, err := ent.DecodeProductCursor(order, "")
+					Expect(err).NotTo(HaveOccurred())
+
+					expected, err := client.Product.Query().Seek(cursor).All(ctx)
+					Expect(err).NotTo(HaveOccurred())
+
+					for index, entity := range expected {
+						Expect(items[index].ID).To(Equal(entity.ID))
+					}
+
+					if first {
+						Expect(nulls(items)).To(Equal([]bool{true, true, true, true, true, true, false, false, false, false}))
+					} else {
+						Expect(nulls(items)).To(Equal([]bool{false, false, false, false, true, true, true, true, true, true}))
+					}
+				},
+				Entry("ascending", "+description,+id", false),
+				Entry("descending", "-description,+id", true),
+				Entry("ascending with nulls first", "+description:nullsfirst,+id", true),
+				Entry("descending with nulls last", "-description:nullslast,+id", false),
+			)
+
+			It("returns the entities page by page backwards", func() {
+				cursor, err := ent.DecodeProductCursor("+description,+id", "")
+				Expect(err).NotTo(HaveOccurred())
+
+				expected, err := client.Product.Query().Seek(cursor).All(ctx)
+				Expect(err).NotTo(HaveOccurred())
+
+				cursor = prevCursor(cursor, expected[6:])
+
+				records := queryBefore(cursor, 3)
+				Expect(records).To(HaveLen(3))
+				Expect(records[0].ID).To(Equal(expected[3].ID))
+				Expect(records[1].ID).To(Equal(expected[4].ID))
+				Expect(records[2].ID).To(Equal(expected[5].ID))
+			})
+
+			It("returns an error when the modifier is unknown", func() {
+				cursor, err := ent.DecodeProductCursor("+description:nullsmiddle", "")
+				Expect(ent.IsOrderSyntaxError(err)).To(BeTrue())
+				Expect(err).To(MatchError("ent: unknown 'nullsmiddle' modifier at offset 13"))
+				Expect(cursor).To(BeNil())
+			})
+		})
+
+		Context("when the order has a field of an edge", func() {
+			var groups map[string][]*ent.Product
+
+			BeforeEach(func() {
+				groups = map[string][]*ent.Product{
+					"Headwear": {entities[0], entities[4], entities[7], entities[9]},
+					"Bottoms":  {entities[1], entities[2], entities[6]},
+				}
+
+				for name, items := range groups {
+					_, err := client.Category.Create().
+						SetName(name).
+						AddProducts(items...).
+						Save(ctx)
+					Expect(err).NotTo(HaveOccurred())
+				}
+			})
+
+			It("returns the identifiers before a cursor in the order of the cursor", func() {
+				_, err := client.Category.Create().
+					SetName("Footwear").
+					Save(ctx)
+				Expect(err).NotTo(HaveOccurred())
+
+				cursor, err := ent.DecodeCategoryCursor("+id", "")
+				Expect(err).NotTo(HaveOccurred())
+
+				records, err := client.Category.Query().Seek(cursor).All(ctx)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(records).To(HaveLen(3))
+
+				cursor, err = cursor.Prev(records[2:])
+				Expect(err).NotTo(HaveOccurred())
+
+				ids, err := client.Category.Query().SeekBefore(cursor).IDs(ctx)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(ids).To(Equal([]int{records[0].ID, records[1].ID}))
+			})
+
+			walk := func(order string) []*ent.Product {
+				cursor, err := ent.DecodeProductCursor(order, "")
+				Expect(err).NotTo(HaveOccurred())
+
+				items := []*ent.Product{}
+
+				for {
+					records := query(cursor, 3)
+
+					if len(records) == 0 {
+						return items
+					}
+
+					items = append(items, records...)
+
+					cursor, err = ent.DecodeProductCursor(order, nextCursor(cursor, records).String())
+					Expect(err).NotTo(HaveOccurred())
+				}
+			}
+
+			// ids returns the identifiers of the products of the category in their order
+			ids := func(name string) []uuid.UUID {
+				predicate := product.Not(product.HasCategory())
+
+				if name != "" {
+					predicate = product.HasCategoryWith(category.Name(name))
+				}
+
+				records, err := client.Product.Query().
+					Where(predicate).
+					Order(ent.Asc(product.FieldID)).
+					All(ctx)
+				Expect(err).NotTo(HaveOccurred())
+
+				values := []uuid.UUID{}
+
+				for _, record := range records {
+					values = append(values, record.ID)
+				}
+
+				return values
+			}
+
+			DescribeTable("returns the entities page by page",
+				func(order string, names ...string) {
+					expected := []uuid.UUID{}
+
+					for _, name := range names {
+						expected = append(expected, ids(name)...)
+					}
+
+					actual := []uuid.UUID{}
+
+					for _, item := range walk(order) {
+						actual = append(actual, item.ID)
+					}
+
+					Expect(actual).To(Equal(expected))
+				},
+				Entry("ascending", "+category.name,+id", "Bottoms", "Headwear", ""),
+				Entry("descending", "-category.name,+id", "", "Headwear", "Bottoms"),
+				Entry("ascending with nulls first", "+category.name:nullsfirst,+id", "", "Bottoms", "Headwear"),
+			)
+
+			It("loads the edge of the seeked entities", func() {
+				cursor, err := ent.DecodeProductCursor("+category.name,+id", "")
+				Expect(err).NotTo(HaveOccurred())
+
+				records := query(cursor, 4)
+				Expect(records).To(HaveLen(4))
+
+				for _, record := range records[:3] {
+					Expect(record.Edges.Category).NotTo(BeNil())
+					Expect(record.Edges.Category.Name).To(Equal("Bottoms"))
+				}
+			})
+
+			It("builds the cursor of an entity without edge", func() {
+				records, err := client.Product.Query().
+					Where(product.Not(product.HasCategory())).
+					WithCategory().
+					All(ctx)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(records).To(HaveLen(3))
+
+				cursor, err := ent.DecodeProductCursor("+category.name,+id", "")
+				Expect(err).NotTo(HaveOccurred())
+
+				cursor, err = cursor.CursorFor(records[0])
+				Expect(err).NotTo(HaveOccurred())
+				Expect(cursor.String()).NotTo(BeEmpty())
+			})
+
+			It("returns an error when the edge of the entity is not loaded", func() {
+				records, err := client.Product.Query().All(ctx)
+				Expect(err).NotTo(HaveOccurred())
+
+				cursor, err := ent.DecodeProductCursor("+category.name,+id", "")
+				Expect(err).NotTo(HaveOccurred())
+
+				next, err := cursor.Next(records)
+				Expect(ent.IsNotLoaded(err)).To(BeTrue())
+				Expect(next).To(BeNil())
+			})
+
+			It("returns an error when the field of the edge is unknown", func() {
+				cursor, err := ent.DecodeProductCursor("+category.title", "")
+				Expect(err).To(MatchError("ent: unknown 'category.title' column"))
+				Expect(cursor).To(BeNil())
+			})
+
+			It("returns the entities of the edge of a parent page by page", func() {
+				parent, err := client.Category.Query().
+					Where(category.Name("Headwear")).
+					Only(ctx)
+				Expect(err).NotTo(HaveOccurred())
+
+				expected, err := parent.QueryProducts().
+					Order(ent.Asc(product.FieldTitle), ent.Asc(product.FieldID)).
+					All(ctx)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(expected).To(HaveLen(4))
+
+				cursor, err := ent.DecodeProductCursor("+title,+id", "")
+				Expect(err).NotTo(HaveOccurred())
+
+				actual := []*ent.Product{}
+
+				for {
+					records, err := parent.QueryProducts().Seek(cursor).Limit(3).All(ctx)
+					Expect(err).NotTo(HaveOccurred())
+
+					if len(records) == 0 {
+						break
+					}
+
+					actual = append(actual, records...)
+
+					cursor, err = cursor.Next(records)
+					Expect(err).NotTo(HaveOccurred())
+				}
+
+				Expect(actual).To(HaveLen(len(expected)))
+
+				for index, item := range actual {
+					Expect(item.ID).To(Equal(expected[index].ID))
+				}
+			})
+
+			Context("when the edge of the parents is seeked", func() {
+				// products returns the titles of the seeked products of each category
+				products := func(client *ent.Client, order string, limit int) (map[string][]string, error) {
+					cursor, err := ent.DecodeProductCursor(order, "")
+					Expect(err).NotTo(HaveOccurred())
+
+					records, err := client.Category.Query().
+						WithProductsSeek(cursor, limit).
+						All(ctx)
+					if err != nil {
+						return nil, err
+					}
+
+					items := map[string][]string{}
+
+					for _, record := range records {
+						items[record.Name] = []string{}
+
+						for _, item := range record.Edges.Products {
+							items[record.Name] = append(items[record.Name], item.Title)
+						}
+					}
+
+					return items, nil
+				}
+
+				It("limits the items of each parent", func() {
+					Expect(products(client, "+title,+id", 2)).To(Equal(map[string][]string{
+						"Bottoms":  {"Pants", "Pants"},
+						"Headwear": {"Cap", "Hat"},
+					}))
+				})
+
+				It("orders the items of each parent", func() {
+					Expect(products(client, "-title,+id", 1)).To(Equal(map[string][]string{
+						"Bottoms":  {"Trousers"},
+						"Headwear": {"Hat"},
+					}))
+				})
+
+				It("returns an error when the server does not support window functions", func() {
+					db.only(dialect.SQLite)
+
+					drv, err := entsql.Open(db.dialect(), db.dsn)
+					Expect(err).NotTo(HaveOccurred())
+
+					// the client shares the migrated database of the spec
+					client := ent.NewClient(ent.Driver(&versionDriver{Driver: drv, version: "3.24.0"}))
+					db.clients = append(db.clients, client)
+
+					items, err := products(client, "+title,+id", 2)
+					Expect(err).To(MatchError("ent: seek of the eager-loaded edges requires window functions, which sqlite3 3.24 does not support"))
+					Expect(items).To(BeNil())
+				})
+			})
+		})
+
+		Describe("Paginate", func() {
+			titles := func(conn *ent.ProductConnection) []string {
+				items := []string{}
+
+				for _, edge := range conn.Edges {
+					items = append(items, edge.Node.Title)
+				}
+
+				return items
+			}
+
+			It("returns the connection page by page", func() {
+				first := 4
+
+				conn, err := client.Product.Query().
+					Paginate(ctx, nil, &first, nil, nil, "+title,+id", ent.WithTotalCount())
+				Expect(err).NotTo(HaveOccurred())
+				Expect(conn.TotalCount).To(Equal(10))
+				Expect(titles(conn)).To(Equal([]string{"Cap", "Hat", "Hat", "Hat"}))
+				Expect(conn.PageInfo.HasNextPage).To(BeTrue())
+				Expect(conn.PageInfo.HasPreviousPage).To(BeFalse())
+				Expect(conn.PageInfo.StartCursor).To(Equal(conn.Edges[0].Cursor))
+				Expect(conn.PageInfo.EndCursor).To(Equal(conn.Edges[3].Cursor))
+
+				conn, err = client.Product.Query().
+					Paginate(ctx, &conn.PageInfo.EndCursor, &first, nil, nil, "+title,+id")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(conn.TotalCount).To(BeZero())
+				Expect(titles(conn)).To(Equal([]string{"Jackets", "Pants", "Pants", "T-Shirt"}))
+				Expect(conn.PageInfo.HasNextPage).To(BeTrue())
+
+				conn, err = client.Product.Query().
+					Paginate(ctx, &conn.PageInfo.EndCursor, &first, nil, nil, "+title,+id")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(titles(conn)).To(Equal([]string{"T-Shirt", "Trousers"}))
+				Expect(conn.PageInfo.HasNextPage).To(BeFalse())
+
+				last := 3
+
+				conn, err = client.Product.Query().
+					Paginate(ctx, nil, nil, &conn.PageInfo.StartCursor, &last, "+title,+id")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(titles(conn)).To(Equal([]string{"Pants", "Pants", "T-Shirt"}))
+				Expect(conn.PageInfo.HasPreviousPage).To(BeTrue())
+				Expect(conn.PageInfo.HasNextPage).To(BeFalse())
+			})
+
+			It("returns the connection between two cursors", func() {
+				first := 10
+
+				conn, err := client.Product.Query().
+					Paginate(ctx, nil, &first, nil, nil, "+title,+id")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(conn.Edges).To(HaveLen(10))
+				Expect(conn.PageInfo.HasNextPage).To(BeFalse())
+
+				after := conn.Edges[1].Cursor
+				before := conn.Edges[6].Cursor
+
+				conn, err = client.Product.Query().
+					Paginate(ctx, &after, nil, &before, nil, "+title,+id")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(titles(conn)).To(Equal([]string{"Hat", "Hat", "Jackets", "Pants"}))
+			})
+
+			It("returns an error when the limit is negative", func() {
+				first := -1
+
+				conn, err := client.Product.Query().
+					Paginate(ctx, nil, &first, nil, nil, "+title,+id")
+				Expect(err).To(MatchError("ent: first must be a non-negative integer"))
+				Expect(conn).To(BeNil())
+			})
+		})
+
+		Describe("Page", func() {
+			titles := func(page *ent.ProductPage) []string {
+				items := []string{}
+
+				for _, item := range page.Items {
+					items = append(items, item.Title)
+				}
+
+				return items
+			}
+
+			It("returns the page with the given number", func() {
+				page, err := client.Product.Query().Page(ctx, 2, 4, "+title")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(titles(page)).To(Equal([]string{"Jackets", "Pants", "Pants", "T-Shirt"}))
+				Expect(page.TotalCount).To(Equal(10))
+				Expect(page.TotalPages).To(Equal(3))
+				Expect(page.Page).To(Equal(2))
+
+				page, err = client.Product.Query().Page(ctx, 3, 4, "+title")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(titles(page)).To(Equal([]string{"T-Shirt", "Trousers"}))
+			})
+
+			It("returns an empty page when the number is out of range", func() {
+				page, err := client.Product.Query().Page(ctx, 4, 4, "-title")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(page.Items).To(BeEmpty())
+				Expect(page.TotalPages).To(Equal(3))
+			})
+
+			It("returns an error when the page is not positive", func() {
+				page, err := client.Product.Query().Page(ctx, 0, 4, "+title")
+				Expect(err).To(MatchError("ent: page must be a positive integer"))
+				Expect(page).To(BeNil())
+			})
+
+			It("returns an error when the column is not sortable", func() {
+				page, err := client.Product.Query().Page(ctx, 1, 4, "+deleted_at")
+				Expect(ent.IsUnsortableColumn(err)).To(BeTrue())
+				Expect(page).To(BeNil())
+			})
+		})
+
+		Describe("Each", func() {
+			var expected []string
+
+			BeforeEach(func() {
+				expected = []string{"Cap", "Hat", "Hat", "Hat", "Jackets", "Pants", "Pants", "T-Shirt", "T-Shirt", "Trousers"}
+			})
+
+			It("calls the function for each item in batches", func() {
+				titles := []string{}
+
+				err := client.Product.Query().Each(ctx, 3, "+title,+id", func(item *ent.Product) error {
+					titles = append(titles, item.Title)
+					return nil
+				})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(titles).To(Equal(expected))
+			})
+
+			It("resumes from the checkpoint of the last processed batch", func() {
+				var (
+					titles     = []string{}
+					checkpoint string
+					failure    = fmt.Errorf("oh no")
+				)
+
+				save := ent.WithCheckpoint(func(cursor string) error {
+					checkpoint = cursor
+					return nil
+				})
+
+				err := client.Product.Query().Each(ctx, 3, "+title,+id", func(item *ent.Product) error {
+					if len(titles) == 4 {
+						return failure
+					}
+
+					titles = append(titles, item.Title)
+					return nil
+				}, save)
+				Expect(err).To(Equal(failure))
+				Expect(checkpoint).NotTo(BeEmpty())
+
+				// the items after the first batch are processed again
+				titles = titles[:3]
+
+				err = client.Product.Query().Each(ctx, 3, "+title,+id", func(item *ent.Product) error {
+					titles = append(titles, item.Title)
+					return nil
+				}, ent.StartAfter(checkpoint), save)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(titles).To(Equal(expected))
+			})
+
+			It("stops when the context is canceled", func() {
+				var (
+					count        = 0
+					cctx, cancel = context.WithCancel(ctx)
+				)
+
+				defer cancel()
+
+				err := client.Product.Query().Each(cctx, 3, "+title,+id", func(item *ent.Product) error {
+					if count++; count == 2 {
+						cancel()
+					}
+					return nil
+				})
+				Expect(err).To(Equal(context.Canceled))
+				Expect(count).To(Equal(2))
+			})
+
+			It("returns an error when the batch size is not positive", func() {
+				err := client.Product.Query().Each(ctx, 0, "+title,+id", func(item *ent.Product) error {
+					return nil
+				})
+				Expect(err).To(MatchError("ent: batch size must be a positive integer"))
+			})
+
+			It("resumes the iterator after any item", func() {
+				iterator, err := client.Product.Query().Iterate(4, "+title,+id")
+				Expect(err).NotTo(HaveOccurred())
+
+				for index := 0; index < 6; index++ {
+					Expect(iterator.Next(ctx)).To(BeTrue())
+				}
+
+				Expect(iterator.Item().Title).To(Equal(expected[5]))
+
+				token, err := iterator.Cursor()
+				Expect(err).NotTo(HaveOccurred())
+
+				iterator, err = client.Product.Query().Iterate(4, "+title,+id", ent.StartAfter(token))
+				Expect(err).NotTo(HaveOccurred())
+
+				titles := []string{}
+
+				for iterator.Next(ctx) {
+					titles = append(titles, iterator.Item().Title)
+				}
+
+				Expect(iterator.Err()).NotTo(HaveOccurred())
+				Expect(titles).To(Equal(expected[6:]))
+			})
+		})
+
+		Describe("CountWithCursor", func() {
+			var cursor *ent.ProductCursor
+
+			BeforeEach(func() {
+				var err error
+
+				cursor, err = ent.DecodeProductCursor("+title,+id", "")
+				Expect(err).NotTo(HaveOccurred())
+
+				cursor = nextCursor(cursor, query(cursor, 4))
+			})
+
+			It("returns the total count and the count after the cursor", func() {
+				count, err := client.Product.Query().Limit(2).CountWithCursor(ctx, cursor)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(count.Total).To(Equal(10))
+				Expect(count.Remaining).To(Equal(6))
+				Expect(count.Estimated).To(BeFalse())
+			})
+
+			It("keeps the filters of the query", func() {
+				count, err := client.Product.Query().
+					Where(product.TitleHasPrefix("T")).
+					CountWithCursor(ctx, cursor)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(count.Total).To(Equal(3))
+				Expect(count.Remaining).To(Equal(3))
+			})
+
+			It("does not count the seek of the query", func() {
+				count, err := client.Product.Query().
+					Seek(cursor).
+					Limit(2).
+					CountWithCursor(ctx, cursor)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(count.Total).To(Equal(10))
+				Expect(count.Remaining).To(Equal(6))
+			})
+
+			It("returns the exact counts when the dialect has no estimates", func() {
+				if db.dialect() == dialect.Postgres {
+					Skip("the counts are estimated by Postgres")
+				}
+
+				count, err := client.Product.Query().CountWithCursor(ctx, cursor, ent.WithEstimate())
+				Expect(err).NotTo(HaveOccurred())
+				Expect(count.Estimated).To(BeFalse())
+				Expect(count.Total).To(Equal(10))
+				Expect(count.Remaining).To(Equal(6))
+			})
+
+			Context("when the count is estimated", func() {
+				BeforeEach(func() {
+					db.only(dialect.Postgres)
+
+					// the estimates are based on the statistics of the table
+					Expect(db.exec(ctx, "ANALYZE products")).To(Succeed())
+				})
+
+				It("returns the estimated counts", func() {
+					count, err := client.Product.Query().CountWithCursor(ctx, cursor, ent.WithEstimate())
+					Expect(err).NotTo(HaveOccurred())
+					Expect(count.Estimated).To(BeTrue())
+					Expect(count.Total).To(BeNumerically("~", 10, 2))
+					Expect(count.Remaining).To(BeNumerically("<=", count.Total))
+				})
+
+				It("returns the connection with the estimated total count", func() {
+					first := 4
+
+					conn, err := client.Product.Query().
+						Paginate(ctx, nil, &first, nil, nil, "+title,+id", ent.WithEstimatedTotalCount())
+					Expect(err).NotTo(HaveOccurred())
+					Expect(conn.TotalCountEstimated).To(BeTrue())
+					Expect(conn.TotalCount).To(BeNumerically("~", 10, 2))
+					Expect(conn.Edges).To(HaveLen(4))
+				})
+			})
+		})
+
+		It("returns the entities page by page backwards", func() {
+			cursor, err := ent.DecodeProductCursor("+title,+id", "")
+			Expect(err).NotTo(HaveOccurred())
+
+			// fetch first page
+			records := query(cursor, 2)
+			Expect(records).To(HaveLen(2))
+			Expect(records[0].Title).To(Equal("Cap"))
+			Expect(records[1].Title).To(Equal("Hat"))
+
+			first := records
+
+			// fetch next page
+			cursor = nextCursor(cursor, records)
+
+			records = query(cursor, 2)
+			Expect(records).To(HaveLen(2))
+			Expect(records[0].Title).To(Equal("Hat"))
+			Expect(records[1].Title).To(Equal("Hat"))
+
+			second := records
+
+			// fetch next page
+			cursor = nextCursor(cursor, records)
+
+			records = query(cursor, 2)
+			Expect(records).To(HaveLen(2))
+			Expect(records[0].Title).To(Equal("Jackets"))
+			Expect(records[1].Title).To(Equal("Pants"))
+
+			// fetch previous page
+			cursor = prevCursor(cursor, records)
+
+			records = queryBefore(cursor, 2)
+			Expect(records).To(HaveLen(2))
+			Expect(records[0].ID).To(Equal(second[0].ID))
+			Expect(records[1].ID).To(Equal(second[1].ID))
+
+			// fetch previous page
+			cursor = prevCursor(cursor, records)
+
+			records = queryBefore(cursor, 2)
+			Expect(records).To(HaveLen(2))
+			Expect(records[0].ID).To(Equal(first[0].ID))
+			Expect(records[1].ID).To(Equal(first[1].ID))
+
+			// there is nothing before the first page
+			cursor = prevCursor(cursor, records)
+
+			records = queryBefore(cursor, 2)
+			Expect(records).To(HaveLen(0))
 		})
 	})
 })
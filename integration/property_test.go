@@ -0,0 +1,304 @@
+package integration_test
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"strings"
+	"time"
+
+	"github.com/facebookincubator/ent/dialect"
+	"github.com/facebookincubator/ent/dialect/sql"
+	"github.com/phogolabs/ent/integration/ent"
+	"github.com/phogolabs/ent/integration/ent/product"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+// The properties are checked for random datasets, orders and page sizes, which
+// are derived from the seed of the suite. A failure is reproduced by running
+// the suite with the same seed (e.g. ginkgo -seed=1234).
+var _ = describeDialects("Pagination Properties", func(db *database) {
+	const (
+		trials = 20
+		orders = 5
+	)
+
+	var (
+		ctx    = context.TODO()
+		client *ent.Client
+		random *rand.Rand
+	)
+
+	BeforeEach(func() {
+		client = db.open()
+		random = rand.New(rand.NewSource(GinkgoRandomSeed()))
+	})
+
+	// populate replaces the products with a random dataset, whose values are
+	// drawn from small sets, so the orders have plenty of ties and NULL values.
+	populate := func() int {
+		var (
+			titles       = []string{"Cap", "cap", "Hat", "Jackets", "Pants", "T-Shirt"}
+			descriptions = []string{"Blue", "Green", "Red"}
+			names        = []string{"Bottoms", "Headwear", "Tops"}
+			categories   = []*ent.Category{}
+			epoch        = time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC)
+		)
+
+		_, err := client.Product.HardDelete().Exec(ctx)
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = client.Category.Delete().Exec(ctx)
+		Expect(err).NotTo(HaveOccurred())
+
+		// the categories may have the same name, so the edge has ties as well
+		for index := 0; index < 3; index++ {
+			entity, err := client.Category.Create().
+				SetName(names[random.Intn(len(names))]).
+				Save(ctx)
+			Expect(err).NotTo(HaveOccurred())
+
+			categories = append(categories, entity)
+		}
+
+		count := random.Intn(len(imap) + 1)
+
+		for index := 0; index < count; index++ {
+			create := client.Product.Create().
+				SetID(imap[index]).
+				SetTitle(titles[random.Intn(len(titles))]).
+				SetCreatedAt(epoch.Add(time.Duration(random.Intn(4)) * time.Hour)).
+				SetUpdatedAt(epoch.Add(time.Duration(random.Intn(4)) * time.Hour))
+
+			if random.Intn(3) > 0 {
+				create.SetDescription(descriptions[random.Intn(len(descriptions))])
+			}
+
+			if random.Intn(3) > 0 {
+				create.SetCategory(categories[random.Intn(len(categories))])
+			}
+
+			_, err := create.Save(ctx)
+			Expect(err).NotTo(HaveOccurred())
+		}
+
+		return count
+	}
+
+	// collations are the collations of the text columns of each dialect.
+	collations := map[string]string{
+		dialect.SQLite:   "NOCASE",
+		dialect.Postgres: "C",
+		dialect.MySQL:    "utf8mb4_general_ci",
+	}
+
+	// order returns a random order over the sortable fields of the product and
+	// the plain ORDER BY of the whole query, which is built from the same terms
+	// without the parser and the seek of the cursors.
+	order := func() (string, []ent.Order) {
+		var (
+			columns  = []string{"title", "created_at", "updated_at", "description", "category.name", "id"}
+			terms    = []string{}
+			expected = []ent.Order{}
+			unique   = false
+			last     = " ASC"
+		)
+
+		for _, index := range random.Perm(len(columns))[:1+random.Intn(len(columns))] {
+			var (
+				column    = columns[index]
+				direction = []string{"+", "-"}[random.Intn(2)]
+				term      = direction + column
+				sort      = map[string]string{"+": " ASC", "-": " DESC"}[direction]
+			)
+
+			switch column {
+			case "title":
+				switch random.Intn(3) {
+				case 0:
+					term += ":ci"
+					expected = append(expected, func(s *sql.Selector) {
+						s.OrderBy("LOWER(" + s.C(column) + ")" + sort)
+					})
+				case 1:
+					collation := collations[db.dialect()]
+					term += ":collate=" + collation
+					expected = append(expected, func(s *sql.Selector) {
+						b := &sql.Builder{}
+						b.SetDialect(s.Dialect())
+						s.OrderBy(s.C(column) + " COLLATE " + b.Quote(collation) + sort)
+					})
+				default:
+					expected = append(expected, func(s *sql.Selector) {
+						s.OrderBy(s.C(column) + sort)
+					})
+				}
+			case "description", "category.name":
+				nulls := []string{"", ":nullsfirst", ":nullslast"}[random.Intn(3)]
+				term += nulls
+
+				// the NULL values are the greatest ones unless the order has a modifier
+				first := nulls == ":nullsfirst" || (nulls == "" && direction == "-")
+
+				expected = append(expected, func(s *sql.Selector) {
+					expr := s.C(column)
+
+					// the name of the category is selected by the foreign key of the product
+					if column == "category.name" {
+						expr = "(SELECT name FROM categories WHERE categories.id = " + s.C(product.CategoryColumn) + ")"
+					}
+
+					if first {
+						s.OrderBy("(" + expr + " IS NULL) DESC")
+					} else {
+						s.OrderBy("(" + expr + " IS NULL) ASC")
+					}
+					s.OrderBy(expr + sort)
+				})
+			default:
+				unique = unique || column == "id"
+				expected = append(expected, func(s *sql.Selector) {
+					s.OrderBy(s.C(column) + sort)
+				})
+			}
+
+			terms = append(terms, term)
+			last = sort
+		}
+
+		// the identifier is the tiebreaker of the orders without it, in the
+		// direction of their last term
+		if !unique {
+			expected = append(expected, func(s *sql.Selector) {
+				s.OrderBy(s.C(product.FieldID) + last)
+			})
+		}
+
+		return strings.Join(terms, ","), expected
+	}
+
+	// ids returns the identifiers of the products.
+	ids := func(items []*ent.Product) []string {
+		values := []string{}
+
+		for _, item := range items {
+			values = append(values, item.ID.String())
+		}
+
+		return values
+	}
+
+	It("returns the same items page by page as the whole ordered query", func() {
+		for trial := 0; trial < trials; trial++ {
+			count := populate()
+
+			for index := 0; index < orders; index++ {
+				var (
+					order, orderBy = order()
+					size           = 1 + random.Intn(7)
+					info           = fmt.Sprintf("seed %d, trial %d, %d items, order %q, page size %d",
+						GinkgoRandomSeed(), trial, count, order, size)
+				)
+
+				cursor, err := ent.DecodeProductCursor(order, "")
+				Expect(err).NotTo(HaveOccurred(), info)
+
+				expected, err := client.Product.Query().Order(orderBy...).All(ctx)
+				Expect(err).NotTo(HaveOccurred(), info)
+				Expect(expected).To(HaveLen(count), info)
+
+				items := []*ent.Product{}
+
+				for page := 0; page <= count; page++ {
+					records, err := client.Product.Query().Seek(cursor).Limit(size).All(ctx)
+					Expect(err).NotTo(HaveOccurred(), info)
+
+					// the page is the matching slice of the whole query
+					start := page * size
+					end := start + size
+
+					if end > count {
+						end = count
+					}
+
+					Expect(ids(records)).To(Equal(ids(expected[start:end])), "%s, page %d", info, page)
+
+					items = append(items, records...)
+
+					if len(records) < size {
+						break
+					}
+
+					// the cursor is round-tripped through its token
+					cursor, err = ent.DecodeProductCursor(order, nextCursor(cursor, records).String())
+					Expect(err).NotTo(HaveOccurred(), info)
+				}
+
+				Expect(ids(items)).To(Equal(ids(expected)), info)
+			}
+		}
+	})
+
+	It("returns the same items page by page backwards as the reversed ordered query", func() {
+		for trial := 0; trial < trials; trial++ {
+			count := populate()
+
+			for index := 0; index < orders; index++ {
+				var (
+					order, orderBy = order()
+					size           = 1 + random.Intn(7)
+					info           = fmt.Sprintf("seed %d, trial %d, %d items, order %q, page size %d",
+						GinkgoRandomSeed(), trial, count, order, size)
+				)
+
+				cursor, err := ent.DecodeProductCursor(order, "")
+				Expect(err).NotTo(HaveOccurred(), info)
+
+				expected, err := client.Product.Query().Order(orderBy...).All(ctx)
+				Expect(err).NotTo(HaveOccurred(), info)
+				Expect(expected).To(HaveLen(count), info)
+
+				// the oracle is walked from its last item
+				reversed := []*ent.Product{}
+
+				for index := len(expected) - 1; index >= 0; index-- {
+					reversed = append(reversed, expected[index])
+				}
+
+				items := []*ent.Product{}
+
+				for page := 0; page <= count; page++ {
+					records, err := client.Product.Query().SeekBefore(cursor).Limit(size).All(ctx)
+					Expect(err).NotTo(HaveOccurred(), info)
+
+					// the page is the matching slice of the whole query, which
+					// is returned in the order of the cursor
+					end := count - page*size
+					start := end - size
+
+					if start < 0 {
+						start = 0
+					}
+
+					Expect(ids(records)).To(Equal(ids(expected[start:end])), "%s, page %d", info, page)
+
+					for index := len(records) - 1; index >= 0; index-- {
+						items = append(items, records[index])
+					}
+
+					if len(records) < size {
+						break
+					}
+
+					// the cursor is round-tripped through its token
+					cursor, err = ent.DecodeProductCursor(order, prevCursor(cursor, records).String())
+					Expect(err).NotTo(HaveOccurred(), info)
+				}
+
+				Expect(ids(items)).To(Equal(ids(reversed)), info)
+			}
+		}
+	})
+})
@@ -0,0 +1,220 @@
+package integration_test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/facebookincubator/ent/dialect"
+	"github.com/phogolabs/ent/integration/ent"
+	"github.com/phogolabs/ent/integration/ent/product"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = describeDialects("Search", func(db *database) {
+	var (
+		ctx      = context.TODO()
+		client   *ent.Client
+		entities []*ent.Product
+	)
+
+	BeforeEach(func() {
+		db.only(dialect.Postgres, dialect.SQLite)
+
+		client = db.open(ent.Debug())
+
+		if err := client.Schema.CreateSearch(ctx); err != nil {
+			if strings.Contains(err.Error(), "no such module: fts5") {
+				Skip("sqlite3 is built without the sqlite_fts5 tag")
+			}
+
+			Expect(err).NotTo(HaveOccurred())
+		}
+
+		entities = []*ent.Product{}
+
+		create := func(name string) {
+			entity, err := client.Product.Create().
+				SetID(imap[len(entities)]).
+				SetTitle(name).
+				Save(ctx)
+
+			Expect(err).NotTo(HaveOccurred())
+			entities = append(entities, entity)
+		}
+
+		create("Red Hat")
+		create("Blue Pants")
+		create("Red Red Hat")
+		create("Red Cap")
+	})
+
+	// search fetches the items one by one by relevance, round-tripping the cursor tokens
+	search := func(query string) []string {
+		const order = "-_score,+id"
+
+		cursor, err := ent.DecodeProductCursor(order, "", ent.WithSearch(query))
+		Expect(err).NotTo(HaveOccurred())
+
+		titles := []string{}
+
+		for {
+			records, err := client.Product.Query().Search(query).Seek(cursor).Limit(1).All(ctx)
+			Expect(err).NotTo(HaveOccurred())
+
+			if len(records) == 0 {
+				break
+			}
+
+			titles = append(titles, records[0].Title)
+
+			cursor, err = ent.DecodeProductCursor(order, nextCursor(cursor, records).String(), ent.WithSearch(query))
+			Expect(err).NotTo(HaveOccurred())
+		}
+
+		return titles
+	}
+
+	It("returns the matching items by relevance", func() {
+		Expect(search("red hat")).To(Equal([]string{"Red Red Hat", "Red Hat"}))
+	})
+
+	It("breaks the ties of relevance by the identifier", func() {
+		Expect(search("RED")).To(Equal([]string{"Red Red Hat", "Red Cap", "Red Hat"}))
+	})
+
+	It("returns no items for an empty query", func() {
+		Expect(search("")).To(BeEmpty())
+	})
+
+	It("quotes the query in the order", func() {
+		Expect(search(`red' hat\`)).To(Equal([]string{"Red Red Hat", "Red Hat"}))
+	})
+
+	It("matches the items by their identifiers after the table is vacuumed", func() {
+		db.only(dialect.SQLite)
+
+		// the rowid of the rows after a deleted one may change by the VACUUM
+		Expect(client.Product.HardDeleteOne(entities[0]).Exec(ctx)).To(Succeed())
+		Expect(db.exec(ctx, "VACUUM")).To(Succeed())
+
+		_, err := client.Product.UpdateOneID(entities[1].ID).SetTitle("Red Pants").Save(ctx)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(search("red")).To(ConsistOf("Red Red Hat", "Red Cap", "Red Pants"))
+		Expect(search("blue")).To(BeEmpty())
+	})
+
+	It("returns the items before a cursor by relevance", func() {
+		cursor, err := ent.DecodeProductCursor("-_score,+id", "", ent.WithSearch("red"))
+		Expect(err).NotTo(HaveOccurred())
+
+		records, err := client.Product.Query().Search("red").Seek(cursor).Limit(2).All(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(records).To(HaveLen(2))
+
+		records, err = client.Product.Query().Search("red").SeekBefore(nextCursor(cursor, records)).All(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(records).To(HaveLen(1))
+		Expect(records[0].Title).To(Equal("Red Red Hat"))
+	})
+
+	It("returns an error when the cursor has no search query", func() {
+		cursor, err := ent.DecodeProductCursor("-_score,+id", "")
+		Expect(err).To(MatchError("ent: '_score' column requires a search query"))
+		Expect(cursor).To(BeNil())
+	})
+})
+
+// recordDriver records the queries of a dialect without a server, which
+// returns no rows.
+type recordDriver struct {
+	dialect string
+	queries []string
+}
+
+func (d *recordDriver) Exec(ctx context.Context, query string, args, v interface{}) error {
+	d.queries = append(d.queries, query)
+	return fmt.Errorf("%s has no server", d.dialect)
+}
+
+func (d *recordDriver) Query(ctx context.Context, query string, args, v interface{}) error {
+	d.queries = append(d.queries, query)
+	return fmt.Errorf("%s has no server", d.dialect)
+}
+
+func (d *recordDriver) Tx(ctx context.Context) (dialect.Tx, error) {
+	return nil, fmt.Errorf("%s has no server", d.dialect)
+}
+
+func (d *recordDriver) Close() error {
+	return nil
+}
+
+func (d *recordDriver) Dialect() string {
+	return d.dialect
+}
+
+var _ = Describe("Search Query", func() {
+	var ctx = context.TODO()
+
+	It("matches the text search vector in Postgres", func() {
+		drv := &recordDriver{dialect: dialect.Postgres}
+		client := ent.NewClient(ent.Driver(drv))
+
+		cursor, err := ent.DecodeProductCursor("-_score,+id", "", ent.WithSearch("red's hat"))
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = client.Product.Query().Search("red's hat").Seek(cursor).All(ctx)
+		Expect(err).To(MatchError("postgres has no server"))
+		Expect(drv.queries).To(HaveLen(1))
+
+		var (
+			vector = `to_tsvector('simple', coalesce("products"."title", ''))`
+			query  = `plainto_tsquery('simple', E'red''s hat')`
+		)
+
+		Expect(drv.queries[0]).To(ContainSubstring("(" + vector + " @@ " + query + ") = TRUE"))
+		Expect(drv.queries[0]).To(ContainSubstring("ORDER BY ts_rank(" + vector + ", " + query + ") DESC"))
+	})
+
+	It("returns an error when the dialect does not support the search", func() {
+		drv := &recordDriver{dialect: dialect.MySQL}
+		client := ent.NewClient(ent.Driver(drv))
+
+		records, err := client.Product.Query().Search("red").All(ctx)
+		Expect(err).To(MatchError("ent: full-text search is not supported by mysql"))
+		Expect(records).To(BeNil())
+
+		count, err := client.Product.Query().Search("red").Clone().Count(ctx)
+		Expect(err).To(MatchError("ent: full-text search is not supported by mysql"))
+		Expect(count).To(BeZero())
+
+		ids, err := client.Product.Query().Search("red").IDs(ctx)
+		Expect(err).To(MatchError("ent: full-text search is not supported by mysql"))
+		Expect(ids).To(BeNil())
+
+		titles, err := client.Product.Query().Search("red").Select(product.FieldTitle).Strings(ctx)
+		Expect(err).To(MatchError("ent: full-text search is not supported by mysql"))
+		Expect(titles).To(BeNil())
+
+		titles, err = client.Product.Query().Search("red").GroupBy(product.FieldTitle).Strings(ctx)
+		Expect(err).To(MatchError("ent: full-text search is not supported by mysql"))
+		Expect(titles).To(BeNil())
+		Expect(drv.queries).To(BeEmpty())
+	})
+
+	It("returns an error when the dialect does not support the score", func() {
+		drv := &recordDriver{dialect: dialect.MySQL}
+		client := ent.NewClient(ent.Driver(drv))
+
+		cursor, err := ent.DecodeProductCursor("-_score,+id", "", ent.WithSearch("red"))
+		Expect(err).NotTo(HaveOccurred())
+
+		records, err := client.Product.Query().Seek(cursor).All(ctx)
+		Expect(err).To(MatchError("ent: full-text search is not supported by mysql"))
+		Expect(records).To(BeNil())
+	})
+})
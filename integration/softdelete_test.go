@@ -0,0 +1,211 @@
+package integration_test
+
+import (
+	"context"
+
+	"github.com/phogolabs/ent/integration/ent"
+	"github.com/phogolabs/ent/integration/ent/product"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = describeDialects("Soft Delete", func(db *database) {
+	var (
+		ctx      = context.TODO()
+		client   *ent.Client
+		entities []*ent.Product
+	)
+
+	BeforeEach(func() {
+		client = db.open(ent.Debug())
+		entities = []*ent.Product{}
+
+		for index, title := range []string{"Cap", "Hat", "Jackets", "Pants", "T-Shirt"} {
+			entity, err := client.Product.Create().
+				SetID(imap[index]).
+				SetTitle(title).
+				Save(ctx)
+			Expect(err).NotTo(HaveOccurred())
+
+			entities = append(entities, entity)
+		}
+
+		// the hat and the pants are soft-deleted
+		Expect(client.Product.DeleteOne(entities[1]).Exec(ctx)).To(Succeed())
+		Expect(client.Product.DeleteOneID(entities[3].ID).Exec(ctx)).To(Succeed())
+	})
+
+	titles := func(items []*ent.Product) []string {
+		values := []string{}
+
+		for _, item := range items {
+			values = append(values, item.Title)
+		}
+
+		return values
+	}
+
+	all := func(query *ent.ProductQuery) []string {
+		records, err := query.Order(ent.Asc(product.FieldTitle)).All(ctx)
+		Expect(err).NotTo(HaveOccurred())
+
+		return titles(records)
+	}
+
+	seek := func(query *ent.ProductQuery, order string) []string {
+		cursor, err := ent.DecodeProductCursor(order, "")
+		Expect(err).NotTo(HaveOccurred())
+
+		records, err := query.Seek(cursor).All(ctx)
+		Expect(err).NotTo(HaveOccurred())
+
+		return titles(records)
+	}
+
+	It("sets the deleted_at field with Delete", func() {
+		entity, err := client.Product.Query().
+			WithDeleted().
+			Where(product.ID(entities[1].ID)).
+			Only(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(entity.DeletedAt).NotTo(BeNil())
+
+		entity, err = client.Product.Get(ctx, entities[0].ID)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(entity.DeletedAt).To(BeNil())
+	})
+
+	It("keeps the time of the entities which are already deleted", func() {
+		deleted, err := client.Product.Query().
+			OnlyDeleted().
+			Where(product.ID(entities[1].ID)).
+			Only(ctx)
+		Expect(err).NotTo(HaveOccurred())
+
+		affected, err := client.Product.Delete().
+			Where(product.TitleIn("Cap", "Hat")).
+			Exec(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(affected).To(Equal(1))
+
+		entity, err := client.Product.Query().
+			OnlyDeleted().
+			Where(product.ID(entities[1].ID)).
+			Only(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(entity.DeletedAt.Equal(*deleted.DeletedAt)).To(BeTrue())
+
+		Expect(all(client.Product.Query())).To(Equal([]string{"Jackets", "T-Shirt"}))
+	})
+
+	It("returns a not found error when a deleted entity is deleted again", func() {
+		err := client.Product.DeleteOne(entities[1]).Exec(ctx)
+		Expect(ent.IsNotFound(err)).To(BeTrue())
+	})
+
+	It("excludes the soft-deleted entities from the queries", func() {
+		Expect(all(client.Product.Query())).To(Equal([]string{"Cap", "Jackets", "T-Shirt"}))
+
+		count, err := client.Product.Query().Count(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(count).To(Equal(3))
+
+		_, err = client.Product.Get(ctx, entities[1].ID)
+		Expect(ent.IsNotFound(err)).To(BeTrue())
+	})
+
+	It("includes the soft-deleted entities with WithDeleted", func() {
+		Expect(all(client.Product.Query().WithDeleted())).To(Equal([]string{"Cap", "Hat", "Jackets", "Pants", "T-Shirt"}))
+	})
+
+	It("includes only the soft-deleted entities with OnlyDeleted", func() {
+		Expect(all(client.Product.Query().OnlyDeleted())).To(Equal([]string{"Hat", "Pants"}))
+	})
+
+	It("keeps the scope of the query when it is cloned", func() {
+		query := client.Product.Query().OnlyDeleted()
+		Expect(all(query.Clone())).To(Equal([]string{"Hat", "Pants"}))
+	})
+
+	It("excludes the soft-deleted entities from the cursors", func() {
+		Expect(seek(client.Product.Query(), "+title,+id")).To(Equal([]string{"Cap", "Jackets", "T-Shirt"}))
+		Expect(seek(client.Product.Query().WithDeleted(), "+title,+id")).To(Equal([]string{"Cap", "Hat", "Jackets", "Pants", "T-Shirt"}))
+		Expect(seek(client.Product.Query().OnlyDeleted(), "-title,+id")).To(Equal([]string{"Pants", "Hat"}))
+	})
+
+	It("keeps the scope of the query page by page", func() {
+		cursor, err := ent.DecodeProductCursor("+title,+id", "")
+		Expect(err).NotTo(HaveOccurred())
+
+		records, err := client.Product.Query().OnlyDeleted().Seek(cursor).Limit(1).All(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(titles(records)).To(Equal([]string{"Hat"}))
+
+		cursor = nextCursor(cursor, records)
+
+		records, err = client.Product.Query().OnlyDeleted().Seek(cursor).Limit(1).All(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(titles(records)).To(Equal([]string{"Pants"}))
+
+		cursor = nextCursor(cursor, records)
+
+		records, err = client.Product.Query().OnlyDeleted().Seek(cursor).Limit(1).All(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(records).To(BeEmpty())
+	})
+
+	It("excludes the soft-deleted entities from the total counts", func() {
+		cursor, err := ent.DecodeProductCursor("+title,+id", "")
+		Expect(err).NotTo(HaveOccurred())
+
+		count, err := client.Product.Query().CountWithCursor(ctx, cursor)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(count.Total).To(Equal(3))
+		Expect(count.Remaining).To(Equal(3))
+
+		first := 2
+
+		conn, err := client.Product.Query().
+			Paginate(ctx, nil, &first, nil, nil, "+title,+id", ent.WithTotalCount())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(conn.TotalCount).To(Equal(3))
+		Expect(conn.Edges).To(HaveLen(2))
+		Expect(conn.PageInfo.HasNextPage).To(BeTrue())
+
+		page, err := client.Product.Query().Page(ctx, 1, 2, "+title")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(titles(page.Items)).To(Equal([]string{"Cap", "Jackets"}))
+		Expect(page.TotalCount).To(Equal(3))
+		Expect(page.TotalPages).To(Equal(2))
+
+		page, err = client.Product.Query().WithDeleted().Page(ctx, 1, 2, "+title")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(titles(page.Items)).To(Equal([]string{"Cap", "Hat"}))
+		Expect(page.TotalCount).To(Equal(5))
+		Expect(page.TotalPages).To(Equal(3))
+	})
+
+	It("restores the soft-deleted entities", func() {
+		Expect(client.Product.RestoreOne(entities[1]).Exec(ctx)).To(Succeed())
+		Expect(all(client.Product.Query())).To(Equal([]string{"Cap", "Hat", "Jackets", "T-Shirt"}))
+
+		affected, err := client.Product.Restore().Save(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(affected).To(Equal(1))
+		Expect(all(client.Product.Query())).To(Equal([]string{"Cap", "Hat", "Jackets", "Pants", "T-Shirt"}))
+	})
+
+	It("removes the entities with HardDelete", func() {
+		affected, err := client.Product.HardDelete().
+			Where(product.TitleIn("Cap", "Hat")).
+			Exec(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(affected).To(Equal(2))
+
+		Expect(client.Product.HardDeleteOne(entities[2]).Exec(ctx)).To(Succeed())
+		Expect(client.Product.HardDeleteOneID(entities[3].ID).Exec(ctx)).To(Succeed())
+
+		Expect(all(client.Product.Query().WithDeleted())).To(Equal([]string{"T-Shirt"}))
+	})
+})
@@ -6,8 +6,10 @@ import (
 	"io/ioutil"
 	"testing"
 
+	_ "github.com/go-sql-driver/mysql"
 	"github.com/google/uuid"
 	_ "github.com/lib/pq"
+	_ "github.com/mattn/go-sqlite3"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 )
@@ -30,4 +32,14 @@ var _ = BeforeSuite(func() {
 		Expect(err).NotTo(HaveOccurred())
 		imap = append(imap, id)
 	}
+
+	for _, server := range dialects {
+		server.start()
+	}
+})
+
+var _ = AfterSuite(func() {
+	for _, server := range dialects {
+		Expect(server.close()).To(Succeed())
+	}
 })
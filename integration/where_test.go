@@ -0,0 +1,173 @@
+package integration_test
+
+import (
+	"context"
+
+	"github.com/phogolabs/ent/integration/ent"
+	"github.com/phogolabs/ent/integration/ent/predicate"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = describeDialects("Where", func(db *database) {
+	var (
+		ctx      = context.TODO()
+		client   *ent.Client
+		entities []*ent.Product
+	)
+
+	BeforeEach(func() {
+		client = db.open(ent.Debug())
+
+		entities = []*ent.Product{}
+
+		create := func(name string, description *string) {
+			entity, err := client.Product.Create().
+				SetID(imap[len(entities)]).
+				SetTitle(name).
+				SetNillableDescription(description).
+				Save(ctx)
+
+			Expect(err).NotTo(HaveOccurred())
+			entities = append(entities, entity)
+		}
+
+		description := "Woolen"
+
+		create("Cap", &description)
+		create("Hat", nil)
+		create("Jackets", &description)
+		create("Pants", nil)
+		create("T-Shirt", nil)
+	})
+
+	titles := func(predicates ...predicate.Product) []string {
+		records, err := client.Product.Query().
+			Where(predicates...).
+			Order(ent.Asc("title")).
+			All(ctx)
+		Expect(err).NotTo(HaveOccurred())
+
+		names := []string{}
+
+		for _, record := range records {
+			names = append(names, record.Title)
+		}
+
+		return names
+	}
+
+	DescribeTable("applies the dynamic predicate",
+		func(field string, op ent.Operator, values []interface{}, expected []string) {
+			where, err := ent.ProductWhere(field, op, values...)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(titles(where)).To(Equal(expected))
+		},
+		Entry("EQ", "title", ent.OperatorEQ, []interface{}{"Hat"}, []string{"Hat"}),
+		Entry("NEQ", "title", ent.OperatorNEQ, []interface{}{"Hat"}, []string{"Cap", "Jackets", "Pants", "T-Shirt"}),
+		Entry("GTE", "title", ent.OperatorGTE, []interface{}{"Pants"}, []string{"Pants", "T-Shirt"}),
+		Entry("LTE", "title", ent.OperatorLTE, []interface{}{"Hat"}, []string{"Cap", "Hat"}),
+		Entry("In", "title", ent.OperatorIn, []interface{}{"Cap", "Pants"}, []string{"Cap", "Pants"}),
+		Entry("In nothing", "title", ent.OperatorIn, []interface{}{}, []string{}),
+		Entry("NotIn", "title", ent.OperatorNotIn, []interface{}{"Cap", "Pants"}, []string{"Hat", "Jackets", "T-Shirt"}),
+		Entry("NotIn nothing", "title", ent.OperatorNotIn, []interface{}{}, []string{"Cap", "Hat", "Jackets", "Pants", "T-Shirt"}),
+		Entry("Contains", "title", ent.OperatorContains, []interface{}{"a"}, []string{"Cap", "Hat", "Jackets", "Pants"}),
+		Entry("HasPrefix", "title", ent.OperatorHasPrefix, []interface{}{"T-"}, []string{"T-Shirt"}),
+		Entry("Between", "title", ent.OperatorBetween, []interface{}{"Hat", "Pants"}, []string{"Hat", "Jackets", "Pants"}),
+		Entry("IsNull", "description", ent.OperatorIsNull, nil, []string{"Hat", "Pants", "T-Shirt"}),
+		Entry("NotNull", "description", ent.OperatorNotNull, nil, []string{"Cap", "Jackets"}),
+	)
+
+	// where returns the dynamic predicate of the operator on the field.
+	where := func(field string, op ent.Operator, values ...interface{}) predicate.Product {
+		predicate, err := ent.ProductWhere(field, op, values...)
+		Expect(err).NotTo(HaveOccurred())
+		return predicate
+	}
+
+	DescribeTable("combines the dynamic predicates",
+		func(predicate func() predicate.Product, expected []string) {
+			Expect(titles(predicate())).To(Equal(expected))
+		},
+		Entry("Not", func() predicate.Product {
+			return ent.Not(ent.Or(ent.EQ("title", "Cap"), ent.IsNull("description")))
+		}, []string{"Jackets"}),
+		Entry("And", func() predicate.Product {
+			return ent.And(ent.GTE("title", "Hat"), ent.Not(ent.HasPrefix("title", "T")))
+		}, []string{"Hat", "Jackets", "Pants"}),
+		Entry("Or", func() predicate.Product {
+			return ent.Or(ent.EQ("title", "Cap"), ent.HasPrefix("title", "T"))
+		}, []string{"Cap", "T-Shirt"}),
+		Entry("Not nothing", func() predicate.Product {
+			return ent.Not(ent.NotIn("title"))
+		}, []string{"Cap", "Hat", "Jackets", "Pants", "T-Shirt"}),
+		Entry("Not dynamic nothing", func() predicate.Product {
+			return ent.Not(where("title", ent.OperatorNotIn))
+		}, []string{"Cap", "Hat", "Jackets", "Pants", "T-Shirt"}),
+		Entry("And nothing", func() predicate.Product {
+			return ent.And()
+		}, []string{"Cap", "Hat", "Jackets", "Pants", "T-Shirt"}),
+		Entry("And with nothing", func() predicate.Product {
+			return ent.And(ent.NotIn("title"), ent.EQ("title", "Hat"))
+		}, []string{"Hat"}),
+		Entry("Or nothing", func() predicate.Product {
+			return ent.Or()
+		}, []string{"Cap", "Hat", "Jackets", "Pants", "T-Shirt"}),
+		Entry("Or with nothing", func() predicate.Product {
+			return ent.Or(ent.EQ("title", "Hat"), where("title", ent.OperatorNotIn))
+		}, []string{"Cap", "Hat", "Jackets", "Pants", "T-Shirt"}),
+	)
+
+	DescribeTable("validates the dynamic predicate",
+		func(field string, op ent.Operator, values []interface{}, message string) {
+			where, err := ent.ProductWhere(field, op, values...)
+			Expect(err).To(MatchError(message))
+			Expect(where).To(BeNil())
+		},
+		Entry("unknown field", "price", ent.OperatorEQ, []interface{}{1}, "ent: unknown 'price' field of product"),
+		Entry("unknown operator", "title", ent.Operator("like"), []interface{}{"Hat"}, "ent: unknown 'like' operator"),
+		Entry("missing value", "title", ent.OperatorEQ, nil, "ent: 'eq' operator expects 1 values, got 0"),
+		Entry("missing bound", "title", ent.OperatorBetween, []interface{}{"Hat"}, "ent: 'between' operator expects 2 values, got 1"),
+		Entry("text operator", "created_at", ent.OperatorContains, []interface{}{"2020"}, "ent: 'created_at' field does not support the 'contains' operator"),
+		Entry("text value", "title", ent.OperatorHasPrefix, []interface{}{1}, "ent: 'has_prefix' operator expects a string value, got int"),
+	)
+
+	DescribeTable("compiles the filter",
+		func(filter string, expected []string) {
+			where, err := ent.ParseProductFilter(filter)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(titles(where)).To(Equal(expected))
+		},
+		Entry("empty", "", []string{"Cap", "Hat", "Jackets", "Pants", "T-Shirt"}),
+		Entry("comparison", "title eq 'Hat' and created_at gt 2020-01-01", []string{"Hat"}),
+		Entry("time", "created_at lt 2020-01-01T00:00:00Z", []string{}),
+		Entry("in", "title in ('Cap', Pants)", []string{"Cap", "Pants"}),
+		Entry("between", "title between 'Hat' and 'Pants' and description ne null", []string{"Jackets"}),
+		Entry("functions", "contains(title, 'a') and not startswith(title, 'H')", []string{"Cap", "Jackets", "Pants"}),
+		Entry("precedence", "title eq 'Cap' or title eq 'Hat' and description eq null", []string{"Cap", "Hat"}),
+		Entry("parentheses", "(title eq 'Cap' or title eq 'Hat') and description eq null", []string{"Hat"}),
+		Entry("quotes", "title eq 'Men''s' OR title GE 'T'", []string{"T-Shirt"}),
+	)
+
+	DescribeTable("returns the position of a filter error",
+		func(filter string, offset int, reason string) {
+			where, err := ent.ParseProductFilter(filter)
+			Expect(ent.IsFilterSyntaxError(err)).To(BeTrue())
+			Expect(where).To(BeNil())
+
+			e := err.(*ent.FilterSyntaxError)
+			Expect(e.Offset).To(Equal(offset))
+			Expect(e.Reason).To(Equal(reason))
+		},
+		Entry("unknown field", "price eq 1", 0, "unknown 'price' field of product"),
+		Entry("unknown operator", "title like 'Hat'", 6, "unknown 'like' operator"),
+		Entry("invalid value", "created_at gt yesterday", 14, "invalid 'yesterday' value of 'created_at' field: expected a time in RFC 3339 or date format"),
+		Entry("text operator", "contains(created_at, '2020')", 9, "'created_at' field does not support the 'contains' operator"),
+		Entry("null comparison", "title gt null", 9, "unexpected 'null'"),
+		Entry("unterminated string", "title eq 'Hat", 9, "unterminated string"),
+		Entry("unclosed parenthesis", "(title eq 'Hat'", 15, "unexpected end of filter"),
+		Entry("trailing token", "title eq 'Hat' title", 15, "unexpected 'title'"),
+	)
+})
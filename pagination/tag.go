@@ -0,0 +1,71 @@
+// Package pagination provides the struct tags read by the pagination templates.
+package pagination
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Tag configures the pagination of a field or a unique edge of an entity schema.
+// The templates read it from the struct tag of the field, which is the only
+// option of a field that is passed to them.
+//
+//	func (Product) Fields() []ent.Field {
+//		return []ent.Field{
+//			field.String("title").
+//				StructTag(pagination.Tag{
+//					Sortable:   true,
+//					Searchable: true,
+//					Orderings:  []string{"+title,+id"},
+//				}.String()),
+//		}
+//	}
+type Tag struct {
+	// Sortable marks a field that can be used in a cursor order. A unique
+	// edge is marked to order by the sortable fields of its type, which are
+	// referred by their path (e.g. "category.name"). All fields and edges are
+	// sortable when none of them is marked. The identifier is always sortable,
+	// since it is used as a tiebreaker.
+	Sortable bool
+	// Searchable marks a text field searched by the Search method of the
+	// query builder, whose relevance is ranked by the virtual '_score' column
+	// of the cursor orders (e.g. "-_score,+id"). The method is generated only
+	// when a field is marked.
+	Searchable bool
+	// Orderings lists the orders used by the pagination, which are usually
+	// given by the first field of the order (e.g. "+title,+id"). An index is
+	// added to the schema of the migration for each of them, and the orders
+	// without a supporting index are logged in debug mode. The columns of an
+	// index are ascending, so an ordering has a single direction and no
+	// modifiers; the code generation fails on the orderings that cannot be
+	// indexed.
+	// An ordering supports the orders in both directions, since its index is
+	// scanned backwards by the opposite order.
+	Orderings []string
+}
+
+// String returns the struct tag of the field.
+func (t Tag) String() string {
+	var (
+		tags    = []string{}
+		options = []string{}
+	)
+
+	if t.Sortable {
+		options = append(options, "sortable")
+	}
+
+	if t.Searchable {
+		options = append(options, "searchable")
+	}
+
+	if len(options) > 0 {
+		tags = append(tags, fmt.Sprintf("pagination:%q", strings.Join(options, ",")))
+	}
+
+	if len(t.Orderings) > 0 {
+		tags = append(tags, fmt.Sprintf("ordering:%q", strings.Join(t.Orderings, ";")))
+	}
+
+	return strings.Join(tags, " ")
+}
@@ -0,0 +1,32 @@
+// Package softdelete provides the schema mixin read by the soft-delete template.
+package softdelete
+
+import (
+	"github.com/facebookincubator/ent"
+	"github.com/facebookincubator/ent/schema/field"
+)
+
+// FieldDeletedAt is the name of the field that marks the soft-deleted entities.
+const FieldDeletedAt = "deleted_at"
+
+// Mixin adds the optional 'deleted_at' field to an entity schema. The Delete
+// builders of its client set the field instead of removing the entities, which
+// are removed by the HardDelete builders and restored by the Restore builders.
+// The queries exclude the soft-deleted entities, unless they are scoped by their
+// WithDeleted or OnlyDeleted methods.
+//
+//	func (Product) Mixin() []ent.Mixin {
+//		return []ent.Mixin{
+//			softdelete.Mixin{},
+//		}
+//	}
+type Mixin struct{}
+
+// Fields of the Mixin.
+func (Mixin) Fields() []ent.Field {
+	return []ent.Field{
+		field.Time(FieldDeletedAt).
+			Optional().
+			Nillable(),
+	}
+}
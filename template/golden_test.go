@@ -0,0 +1,405 @@
+package template_test
+
+import (
+	"flag"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+	"text/template/parse"
+	"time"
+
+	"github.com/facebookincubator/ent"
+	"github.com/facebookincubator/ent/entc"
+	"github.com/facebookincubator/ent/entc/gen"
+	"github.com/facebookincubator/ent/entc/load"
+	"github.com/facebookincubator/ent/schema/edge"
+	"github.com/facebookincubator/ent/schema/field"
+	"github.com/google/uuid"
+	"github.com/phogolabs/ent/pagination"
+	"github.com/phogolabs/ent/softdelete"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+// update rewrites the golden files with the output of the templates:
+//
+//	go test ./template -update
+var update = flag.Bool("update", false, "update the golden files")
+
+var _ = Describe("Templates", func() {
+	// templates returns the templates of the repository, which are not
+	// sub-templates.
+	templates := func() []*template.Template {
+		tmpl, err := template.New("external").Funcs(gen.Funcs).ParseGlob("*.tmpl")
+		Expect(err).NotTo(HaveOccurred())
+
+		items := []*template.Template{}
+
+		for _, t := range tmpl.Templates() {
+			if t.Tree == nil || parse.IsEmptyTree(t.Root) || strings.HasPrefix(t.Name(), "dialect/") {
+				continue
+			}
+
+			items = append(items, t)
+		}
+
+		return items
+	}
+
+	// overrides returns the templates of entc, which are overridden by the
+	// templates of the repository.
+	overrides := func() []gen.TypeTemplate {
+		names := map[string]bool{}
+
+		for _, t := range templates() {
+			names[t.Name()] = true
+		}
+
+		items := []gen.TypeTemplate{}
+
+		for _, t := range gen.Templates {
+			if names[t.Name] {
+				items = append(items, t)
+			}
+		}
+
+		return items
+	}
+
+	// files returns the files generated by the templates of the repository,
+	// whose output is compared with the golden files. The templates which
+	// override the ones of entc generate the files of the nodes of the graph.
+	files := func(graph *gen.Graph) []string {
+		names := []string{}
+
+		for _, name := range graphTemplates(templates(), overrides()) {
+			format := name + ".go"
+
+			// the templates which override the graph templates of entc keep their files
+			for _, t := range gen.GraphTemplates {
+				if t.Name == name {
+					format = t.Format
+				}
+			}
+
+			names = append(names, format)
+		}
+
+		for _, t := range overrides() {
+			for _, node := range graph.Nodes {
+				names = append(names, t.Format(node))
+			}
+		}
+
+		Expect(names).NotTo(BeEmpty())
+		return names
+	}
+
+	// generate generates the code of the schemas in the target directory, and
+	// returns the error of the generation.
+	generate := func(fixture, target string, schemas ...ent.Interface) (*gen.Graph, error) {
+		config := &gen.Config{
+			Target:  target,
+			Package: "github.com/phogolabs/ent/template/testdata/" + fixture + "/ent",
+			// the runtime of the default values imports the schema package
+			Schema: "github.com/phogolabs/ent/template/testdata/" + fixture + "/schema",
+			IDType: &field.TypeInfo{Type: field.TypeInt},
+		}
+
+		// entc detects the external templates only at the first generation of
+		// the process, so they are registered as graph templates
+		for _, name := range graphTemplates(templates(), overrides()) {
+			registered := false
+
+			for _, t := range gen.GraphTemplates {
+				registered = registered || t.Name == name
+			}
+
+			if !registered {
+				gen.GraphTemplates = append(gen.GraphTemplates, gen.GraphTemplate{Name: name, Format: name + ".go"})
+			}
+		}
+
+		// the glob skips the golden files of the testdata directory
+		for _, opt := range []entc.Option{entc.Storage("sql"), entc.TemplateGlob("*.tmpl")} {
+			Expect(opt(config)).To(Succeed())
+		}
+
+		nodes := []*load.Schema{}
+
+		for _, item := range schemas {
+			data, err := load.MarshalSchema(item)
+			Expect(err).NotTo(HaveOccurred())
+
+			node, err := load.UnmarshalSchema(data)
+			Expect(err).NotTo(HaveOccurred())
+
+			nodes = append(nodes, node)
+		}
+
+		graph, err := gen.NewGraph(config, nodes...)
+		Expect(err).NotTo(HaveOccurred())
+		return graph, graph.Gen()
+	}
+
+	DescribeTable("renders the golden files",
+		func(fixture string, schemas ...ent.Interface) {
+			target, err := ioutil.TempDir("", "ent-golden")
+			Expect(err).NotTo(HaveOccurred())
+			defer os.RemoveAll(target)
+
+			graph, err := generate(fixture, target, schemas...)
+			Expect(err).NotTo(HaveOccurred())
+
+			for _, name := range files(graph) {
+				actual, err := ioutil.ReadFile(filepath.Join(target, name))
+				Expect(err).NotTo(HaveOccurred())
+
+				golden := filepath.Join("testdata", fixture, name+".golden")
+
+				if *update {
+					Expect(os.MkdirAll(filepath.Dir(golden), 0755)).To(Succeed())
+					Expect(ioutil.WriteFile(golden, actual, 0644)).To(Succeed())
+					continue
+				}
+
+				expected, err := ioutil.ReadFile(golden)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(string(actual)).To(Equal(string(expected)), "%s does not match %s", name, golden)
+			}
+		},
+		Entry("int identifiers", "int_id", User{}),
+		Entry("UUID identifiers", "uuid_id", Product{}),
+		Entry("string identifiers", "string_id", Tag{}),
+		Entry("nillable fields", "nillable", Profile{}),
+		Entry("enum fields", "enum", Ticket{}),
+		Entry("no fields", "no_fields", Marker{}),
+		Entry("soft-deleted entities", "soft_delete", Note{}),
+		Entry("edges", "edges", Author{}, Book{}, Shelf{}),
+	)
+
+	DescribeTable("returns an error when an ordering cannot be indexed",
+		func(ordering, reason string) {
+			target, err := ioutil.TempDir("", "ent-golden")
+			Expect(err).NotTo(HaveOccurred())
+			defer os.RemoveAll(target)
+
+			_, err = generate("invalid", target, Label{Ordering: ordering})
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("ent: ordering '%s' of Label cannot be indexed: %s", ordering, reason))
+		},
+		Entry("modifier", "+name:ci,+id", "column 'name' has a modifier"),
+		Entry("mixed directions", "-name,+id", "column 'id' has a mixed direction"),
+		Entry("edge", "+author.name,+id", "column 'author.name' is not in the labels table"),
+		Entry("no direction", "name,+id", "term 'name' has no direction"),
+	)
+})
+
+// graphTemplates returns the names of the templates, which generate the files
+// of the graph, since they do not override the templates of the nodes.
+func graphTemplates(templates []*template.Template, overrides []gen.TypeTemplate) []string {
+	names := []string{}
+
+	for _, t := range templates {
+		overridden := false
+
+		for _, o := range overrides {
+			overridden = overridden || o.Name == t.Name()
+		}
+
+		if !overridden {
+			names = append(names, t.Name())
+		}
+	}
+
+	return names
+}
+
+// User is a fixture with int identifiers.
+type User struct {
+	ent.Schema
+}
+
+// Fields of the User.
+func (User) Fields() []ent.Field {
+	return []ent.Field{
+		field.String("name").
+			StructTag(pagination.Tag{
+				Sortable:  true,
+				Orderings: []string{"+name,+id"},
+			}.String()),
+		field.Int("age").
+			StructTag(pagination.Tag{Sortable: true}.String()),
+	}
+}
+
+// Product is a fixture with UUID identifiers.
+type Product struct {
+	ent.Schema
+}
+
+// Fields of the Product.
+func (Product) Fields() []ent.Field {
+	return []ent.Field{
+		field.
+			UUID("id", uuid.UUID{}).
+			Immutable().
+			Default(uuid.New),
+		field.String("title").
+			StructTag(pagination.Tag{Searchable: true}.String()),
+		field.Time("created_at").
+			Default(time.Now).
+			Immutable().
+			StructTag(pagination.Tag{Orderings: []string{"-created_at,-id"}}.String()),
+	}
+}
+
+// Tag is a fixture with string identifiers.
+type Tag struct {
+	ent.Schema
+}
+
+// Fields of the Tag.
+func (Tag) Fields() []ent.Field {
+	return []ent.Field{
+		field.String("id"),
+		field.String("name"),
+	}
+}
+
+// Label is a fixture with an ordering, which cannot be indexed.
+type Label struct {
+	ent.Schema
+	Ordering string
+}
+
+// Fields of the Label.
+func (l Label) Fields() []ent.Field {
+	return []ent.Field{
+		field.String("name").
+			StructTag(pagination.Tag{Orderings: []string{l.Ordering}}.String()),
+	}
+}
+
+// Profile is a fixture with nillable fields.
+type Profile struct {
+	ent.Schema
+}
+
+// Fields of the Profile.
+func (Profile) Fields() []ent.Field {
+	return []ent.Field{
+		field.String("nickname").
+			Optional().
+			Nillable(),
+		field.Time("born_at").
+			Optional().
+			Nillable(),
+	}
+}
+
+// Ticket is a fixture with enum fields.
+type Ticket struct {
+	ent.Schema
+}
+
+// Fields of the Ticket.
+func (Ticket) Fields() []ent.Field {
+	return []ent.Field{
+		field.Enum("status").
+			Values("open", "closed"),
+		field.Int("priority"),
+	}
+}
+
+// Marker is a fixture without fields.
+type Marker struct {
+	ent.Schema
+}
+
+// Note is a fixture with soft-deleted entities.
+type Note struct {
+	ent.Schema
+}
+
+// Mixin of the Note.
+func (Note) Mixin() []ent.Mixin {
+	return []ent.Mixin{
+		softdelete.Mixin{},
+	}
+}
+
+// Fields of the Note.
+func (Note) Fields() []ent.Field {
+	return []ent.Field{
+		field.String("text"),
+	}
+}
+
+// Author is a fixture with a one-to-many edge.
+type Author struct {
+	ent.Schema
+}
+
+// Fields of the Author.
+func (Author) Fields() []ent.Field {
+	return []ent.Field{
+		field.String("name"),
+	}
+}
+
+// Edges of the Author.
+func (Author) Edges() []ent.Edge {
+	return []ent.Edge{
+		edge.To("books", Book.Type),
+	}
+}
+
+// Book is a fixture ordered by the fields of a unique edge, which has a
+// many-to-many edge.
+type Book struct {
+	ent.Schema
+}
+
+// Fields of the Book.
+func (Book) Fields() []ent.Field {
+	return []ent.Field{
+		field.String("title").
+			StructTag(pagination.Tag{Sortable: true}.String()),
+	}
+}
+
+// Edges of the Book.
+func (Book) Edges() []ent.Edge {
+	return []ent.Edge{
+		edge.From("author", Author.Type).
+			Ref("books").
+			Unique().
+			StructTag(pagination.Tag{Sortable: true}.String()),
+		edge.To("shelves", Shelf.Type),
+	}
+}
+
+// Shelf is a fixture with a many-to-many edge.
+type Shelf struct {
+	ent.Schema
+}
+
+// Fields of the Shelf.
+func (Shelf) Fields() []ent.Field {
+	return []ent.Field{
+		field.String("name"),
+	}
+}
+
+// Edges of the Shelf.
+func (Shelf) Edges() []ent.Edge {
+	return []ent.Edge{
+		edge.From("books", Book.Type).
+			Ref("shelves"),
+	}
+}